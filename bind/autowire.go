@@ -0,0 +1,102 @@
+package bind
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// AutoWire - Resolve interfaces without a binding by assignability.
+//
+// This is an option of Configure. When an interface is requested that
+// isn't bound in the configured context or its parents, all bindings
+// of the context and its parents for the same key are searched for a
+// type that is assignable to the interface. If there is exactly one,
+// it is used as if the interface was bound to it with Implementation.
+// Bindings that resolve to the same binding, like an Implementation
+// and its target, count as one.
+//
+// The resolution fails with ErrAmbiguous, listing all candidates, if
+// more than one binding is assignable to the interface.
+//
+// The option applies to the configured context and its children.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx, bind.AutoWire(),
+//    bind.Once[*sqlDBImpl]())
+//
+//  db := bind.Get[Database](ctx) // the *sqlDBImpl instance
+func AutoWire() Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.autoWiring = true
+			return nil
+		},
+	}
+}
+
+// autoWireCandidate is a binding assignable to an interface.
+type autoWireCandidate struct {
+	binding Binding
+	layer   int
+}
+
+// autoWire finds the unique binding of bs or its parents for key k
+// that is assignable to the interface t.
+//
+// If there is more than one candidate the result is a binding that
+// fails with ErrAmbiguous.
+func (bs *bindings) autoWire(t reflect.Type, k string) (Binding, int, bool) {
+	var candidates []autoWireCandidate
+
+	seen := make(map[reflect.Type]bool)
+	layer := 0
+
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+
+		for typ, typeScope := range bb.bindings {
+			if b, ok := typeScope[k]; ok && !seen[typ] && typ != t && typ.AssignableTo(t) {
+				seen[typ] = true
+				candidates = append(candidates, autoWireCandidate{binding: b, layer: layer})
+			}
+		}
+
+		bb.mut.RUnlock()
+
+		layer++
+	}
+
+	// candidates that resolve to the same binding are the same
+	var unique []autoWireCandidate
+
+	concrete := make(map[Binding]bool)
+
+	for _, c := range candidates {
+		if b := bs.concrete(c.binding); !concrete[b] {
+			concrete[b] = true
+			unique = append(unique, c)
+		}
+	}
+
+	switch len(unique) {
+	case 0:
+		return nil, 0, false
+	case 1:
+		return unique[0].binding, unique[0].layer, true
+	}
+
+	names := make([]string, len(unique))
+
+	for i, c := range unique {
+		names[i] = c.binding.typ().String()
+	}
+
+	sort.Strings(names)
+
+	err := fmt.Errorf("%w, candidates are %s", bindingError(ErrAmbiguous, t, k), strings.Join(names, ", "))
+
+	return &failedBind{t: t, key: k, err: err}, 0, true
+}
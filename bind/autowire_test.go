@@ -0,0 +1,119 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type autoStore interface {
+	Load() string
+}
+
+type autoMemStore struct {
+	Name string `bind:"name"`
+}
+
+func (s *autoMemStore) Load() string { return s.Name }
+
+type autoFileStore struct{}
+
+func (s *autoFileStore) Load() string { return "file" }
+
+type autoService struct {
+	Store autoStore `bind:"-"`
+}
+
+func TestAutoWire(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("mem").For("name"),
+		bind.Once[*autoMemStore]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryNew[*autoService](ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding without AutoWire, got %v", err)
+	}
+
+	ctx, err = bind.Configure(ctx, bind.AutoWire())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	svc := bind.New[*autoService](ctx)
+
+	if svc.Store != bind.Get[*autoMemStore](ctx) {
+		t.Errorf("expected the bound *autoMemStore, got %v", svc.Store)
+	}
+}
+
+func TestAutoWire_Ambiguous(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.AutoWire(),
+		bind.String("mem").For("name"),
+		bind.Once[*autoMemStore]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.Type[*autoFileStore]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	_, err = bind.TryGet[autoStore](child)
+
+	if !errors.Is(err, bind.ErrAmbiguous) {
+		t.Errorf("expected ErrAmbiguous, got %v", err)
+		return
+	}
+
+	for _, candidate := range []string{"*bind_test.autoFileStore", "*bind_test.autoMemStore"} {
+		if !strings.Contains(err.Error(), candidate) {
+			t.Errorf("expected %s to be a candidate, got %s", candidate, err)
+		}
+	}
+
+	if err = bind.Validate(child); err != nil {
+		t.Errorf("expected no error without dependencies on autoStore, got %s", err)
+	}
+
+	if _, err = bind.TryNew[*autoService](child); !errors.Is(err, bind.ErrAmbiguous) {
+		t.Errorf("expected ErrAmbiguous, got %v", err)
+	}
+}
+
+func TestAutoWire_SameBinding(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.AutoWire(),
+		bind.Implementation[autoStore, *autoFileStore](),
+		bind.Once[*autoFileStore]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	type reader interface{ Load() string }
+
+	if v := bind.Get[reader](ctx); v != bind.Get[*autoFileStore](ctx) {
+		t.Errorf("expected the *autoFileStore instance, got %v", v)
+	}
+}
@@ -0,0 +1,93 @@
+package bind_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type benchConfig struct {
+	Host string `bind:"host"`
+	Port int    `bind:"port"`
+}
+
+type benchRepository struct {
+	Config *benchConfig `bind:"-"`
+	Name   string       `bind:"name"`
+}
+
+type benchService struct {
+	Users  *benchRepository `bind:"users"`
+	Orders *benchRepository `bind:"orders"`
+	Config *benchConfig     `bind:"-"`
+}
+
+type benchHandler struct {
+	Users    *benchService `bind:"-"`
+	Orders   *benchService `bind:"-"`
+	Fallback string        `bind:"fallback,default=none"`
+	Ignored  string
+}
+
+// benchContext returns a context with three layers, the bindings of the
+// graph are spread across all of them.
+func benchContext(b *testing.B) context.Context {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Instance[string]("localhost").For("host"),
+		bind.Int(5432).For("port"),
+		bind.Type[*benchConfig]())
+
+	if err == nil {
+		ctx, err = bind.Configure(ctx,
+			bind.Instance[string]("repository").For("name"),
+			bind.Type[*benchRepository]().For("users"),
+			bind.Type[*benchRepository]().For("orders"))
+	}
+
+	if err == nil {
+		ctx, err = bind.Configure(ctx,
+			bind.Type[*benchService]())
+	}
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return ctx
+}
+
+func BenchmarkNew(b *testing.B) {
+	ctx := benchContext(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		bind.New[*benchHandler](ctx)
+	}
+}
+
+func BenchmarkNew_Parallel(b *testing.B) {
+	ctx := benchContext(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			bind.New[*benchHandler](ctx)
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	ctx := benchContext(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		bind.Get[*benchService](ctx)
+	}
+}
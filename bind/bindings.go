@@ -1,6 +1,7 @@
 package bind
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
@@ -8,30 +9,55 @@ import (
 // Initializer interface is used to let instances know about their creation.
 //
 // If a type implements the Initializer interface the InitAfter method
-// is called after a type was initialized and bindings have completed.
+// is called after a type was initialized and bindings have completed,
+// i.e. after its fields, Inject methods and setters have been injected.
 type Initializer interface {
 	// InitAfter bindings happened
 	InitAfter() (err error)
 }
 
+// Disposer interface is used to let instances know about their destruction.
+//
+// If a type implements the Disposer interface the Dispose method is called
+// when the context that created the instance is shut down. Types that
+// implement io.Closer instead are closed.
+type Disposer interface {
+	// Dispose the instance and release its resources
+	Dispose() (err error)
+}
+
 // Binding represents a binding for a specific type.
 type Binding interface {
 	// For - Scope this binding for a specific key.
 	For(key string) Binding
 
+	// As - Qualify this binding by the type of q.
+	//
+	// Unlike keys given with For, qualifiers are types and thus checked
+	// by the compiler. Qualified bindings are injected into fields of
+	// type Named.
+	As(q any) Binding
+
 	// typ is the type of this binding.
 	typ() reflect.Type
 
 	// scope of this binding.
 	scope() string
 
-	// solve this binding.
-	solve() (res reflect.Value, init bool, err error)
+	// solve this binding within the bindings bs as part of resolution r.
+	solve(bs *bindings, r *resolution) (res reflect.Value, init bool, err error)
 
 	// eager is true if the binding should be solved during configuration
 	eager() bool
 }
 
+// bindingConfigurer is implemented by bindings that configure the
+// bindings of a context instead of binding a type.
+type bindingConfigurer interface {
+	// configure the bindings bs; the lock of bs is held.
+	configure(bs *bindings) error
+}
+
 // bindingTo represents an edge to another type.
 type bindingTo interface {
 	// typTo is the type this binding points to.
@@ -114,9 +140,69 @@ func Int(i int) Binding { return Instance[int](i) }
 
 // Provider - Bind a function f to type T.
 func Provider[T any](f func() (T, error)) Binding {
+	return &providerBind[T]{f: func(context.Context) (T, error) { return f() }}
+}
+
+// ProviderCtx - Bind a function f to type T.
+//
+// The function f receives the context in which T is resolved. This allows
+// the provider to resolve its own dependencies and to honour cancellation
+// and deadlines of the context.
+//
+// Dependencies resolved via ctx aren't part of the current resolution,
+// hence they aren't validated. Cycles are still detected: resolving a
+// binding via ctx that is already being solved fails with ErrCycle. Use
+// Constructor to declare dependencies as parameters instead.
+//
+// Example
+//
+//  bind.Configure(ctx,
+//    bind.ProviderCtx[*sql.DB](func(ctx context.Context) (*sql.DB, error) {
+//      db, err := sql.Open("mysql", bind.For[string](ctx, "dsn"))
+//      if err != nil {
+//        return nil, err
+//      }
+//      return db, db.PingContext(ctx)
+//    }))
+func ProviderCtx[T any](f func(ctx context.Context) (T, error)) Binding {
 	return &providerBind[T]{f: f}
 }
 
+// Constructor - Bind a constructor function fn to type T.
+//
+// The function fn may accept any number of parameters. Each parameter
+// is resolved from the bindings of the context without a scope. A
+// parameter of type context.Context receives the context in which T
+// is resolved. The first result of fn must be assignable to T.
+// Optionally fn may return an error as its second result.
+//
+// This is useful to reuse existing constructors and to keep fields
+// of a type private.
+//
+// Example
+//
+//  func NewService(db Database, log *Logger) (*Service, error) {
+//    return &Service{db: db, log: log}, nil
+//  }
+//
+//  bind.Configure(ctx,
+//    bind.Implementation[Database, *DBImpl](),
+//    bind.Type[*Logger](),
+//    bind.Constructor[*Service](NewService))
+//
+// Since the signature of fn can't be checked at compile time this
+// method panics if fn isn't a function of the above form.
+func Constructor[T any](fn any) Binding {
+	f := reflect.ValueOf(fn)
+	t := typeOf[T]()
+
+	if err := checkConstructor(t, f); err != nil {
+		panic(err)
+	}
+
+	return &ctorBind[T]{f: f}
+}
+
 // Once - Bind T exactly once.
 //
 // Once bindings are evaluated eager when a context is configured.
@@ -141,6 +227,30 @@ func ImplementationOnce[T, U any]() Binding {
 	}
 }
 
+// OnceLazy - Bind T exactly once when it is requested first.
+//
+// Unlike Once bindings, lazy bindings are evaluated when they are
+// requested for the first time. Concurrent callers wait for the
+// initialization to complete and receive the same instance.
+//
+// Errors are returned to the caller and the next request tries
+// to initialize the instance again.
+func OnceLazy[T any]() Binding {
+	return ImplementationOnceLazy[T, T]()
+}
+
+// ImplementationOnceLazy - Bind U exactly once for T when it is requested first.
+//
+// See OnceLazy for more information.
+func ImplementationOnceLazy[T, U any]() Binding {
+	mustBeAssignable[T, U]()
+	return &onceBind[T, U]{
+		lazy:     true,
+		typeFrom: typeOf[T](),
+		typeTo:   typeOf[U](),
+	}
+}
+
 // typeBind represents a bind of a type From to type To
 type typeBind[From, To any] struct {
 	key      string
@@ -152,8 +262,11 @@ func (b *typeBind[From, To]) typ() reflect.Type   { return b.typeFrom }
 func (b *typeBind[From, To]) typTo() reflect.Type { return b.typeTo }
 func (b *typeBind[From, To]) scope() string       { return b.key }
 func (b *typeBind[From, To]) eager() bool         { return false }
+func (b *typeBind[From, To]) kind() Kind          { return KindType }
+
+func (b *typeBind[From, To]) deps() ([]dependency, error) { return fieldDeps(b.typeTo) }
 
-func (b *typeBind[From, To]) solve() (value reflect.Value, init bool, err error) {
+func (b *typeBind[From, To]) solve(*bindings, *resolution) (value reflect.Value, init bool, err error) {
 	value, err = alloc(b.typeTo)
 	init = true
 	return
@@ -164,32 +277,47 @@ func (b *typeBind[From, To]) For(k string) Binding {
 	return b
 }
 
+func (b *typeBind[From, To]) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
+
 type instBind[T, U any] struct {
 	key  string
 	inst reflect.Value // of U
 }
 
-func (b *instBind[T, U]) typ() reflect.Type                   { return typeOf[T]() }
-func (b *instBind[T, U]) scope() string                       { return b.key }
-func (b *instBind[T, U]) eager() bool                         { return false }
-func (b *instBind[T, U]) solve() (reflect.Value, bool, error) { return b.inst, false, nil }
+func (b *instBind[T, U]) typ() reflect.Type { return typeOf[T]() }
+func (b *instBind[T, U]) scope() string     { return b.key }
+func (b *instBind[T, U]) eager() bool       { return false }
+func (b *instBind[T, U]) kind() Kind        { return KindInstance }
+
+func (b *instBind[T, U]) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	return b.inst, false, nil
+}
 
 func (b *instBind[T, U]) For(k string) Binding {
 	b.key = k
 	return b
 }
 
+func (b *instBind[T, U]) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
+
 type providerBind[T any] struct {
 	key string
-	f   func() (T, error)
+	f   func(context.Context) (T, error)
 }
 
 func (b *providerBind[T]) typ() reflect.Type { return typeOf[T]() }
 func (b *providerBind[T]) scope() string     { return b.key }
 func (b *providerBind[T]) eager() bool       { return false }
+func (b *providerBind[T]) kind() Kind        { return KindProvider }
 
-func (b *providerBind[T]) solve() (reflect.Value, bool, error) {
-	res, err := b.f()
+func (b *providerBind[T]) deps() ([]dependency, error) { return fieldDeps(typeOf[T]()) }
+
+func (b *providerBind[T]) solve(_ *bindings, r *resolution) (reflect.Value, bool, error) {
+	res, err := b.f(r.context())
 	return reflect.ValueOf(res), true, err
 }
 
@@ -198,10 +326,96 @@ func (b *providerBind[T]) For(k string) Binding {
 	return b
 }
 
-// onceBind represents a bind of a type From to type To that's solved once
+func (b *providerBind[T]) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
+
+// ctorBind represents a bind of a type T to a constructor function.
+type ctorBind[T any] struct {
+	key string
+	f   reflect.Value
+}
+
+func (b *ctorBind[T]) typ() reflect.Type { return typeOf[T]() }
+func (b *ctorBind[T]) scope() string     { return b.key }
+func (b *ctorBind[T]) eager() bool       { return false }
+func (b *ctorBind[T]) kind() Kind        { return KindConstructor }
+
+func (b *ctorBind[T]) deps() (deps []dependency, err error) {
+	ft := b.f.Type()
+
+	for i := 0; i < ft.NumIn(); i++ {
+		if ft.In(i) != contextType {
+			deps = append(deps, paramDependency(ft.In(i)))
+		}
+	}
+
+	fields, err := fieldDeps(ft.Out(0))
+	deps = append(deps, fields...)
+
+	return
+}
+
+func (b *ctorBind[T]) solve(bs *bindings, r *resolution) (value reflect.Value, init bool, err error) {
+	ft := b.f.Type()
+	args := make([]reflect.Value, ft.NumIn())
+
+	for i := range args {
+		if args[i], err = bs.param(r, ft.In(i), ""); err != nil {
+			return
+		}
+	}
+
+	out := b.f.Call(args)
+
+	if len(out) == 2 && !out[1].IsNil() {
+		err = out[1].Interface().(error)
+		return
+	}
+
+	value = out[0]
+	init = true
+
+	return
+}
+
+// param resolves a value of type t in r for a parameter of a function
+// that belongs to field, e.g. a constructor or an Inject method.
+//
+// A parameter of type context.Context receives the context of r.
+func (bs *bindings) param(r *resolution, t reflect.Type, field string) (reflect.Value, error) {
+	if t == contextType {
+		return reflect.ValueOf(r.context()), nil
+	}
+
+	d := paramDependency(t)
+	d.field = field
+	v, err := bs.get(r, d)
+
+	if err != nil {
+		return v, err
+	}
+
+	// values of bindings like Type[Config] are allocated as pointers
+	return valueOf(t, d.box(v)), nil
+}
+
+func (b *ctorBind[T]) For(k string) Binding {
+	b.key = k
+	return b
+}
+
+func (b *ctorBind[T]) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
+
+// onceBind represents a bind of a type From to type To that's solved once.
+//
+// The instance is held by the bindings the binding is configured in, hence
+// a module that is configured in multiple contexts yields one instance per
+// context.
 type onceBind[From, To any] struct {
-	done     bool
-	inst     reflect.Value
+	lazy     bool
 	key      string
 	typeFrom reflect.Type
 	typeTo   reflect.Type
@@ -210,21 +424,20 @@ type onceBind[From, To any] struct {
 func (b *onceBind[From, To]) typ() reflect.Type   { return b.typeFrom }
 func (b *onceBind[From, To]) typTo() reflect.Type { return b.typeTo }
 func (b *onceBind[From, To]) scope() string       { return b.key }
-func (b *onceBind[From, To]) eager() bool         { return true }
+func (b *onceBind[From, To]) eager() bool         { return !b.lazy }
+func (b *onceBind[From, To]) kind() Kind          { return KindOnce }
 
-func (b *onceBind[From, To]) solve() (value reflect.Value, init bool, err error) {
-	// Since we're using eager initialization there are no two threads
-	// competing for solve and this code is therefor safe.
-	if b.done {
-		value = b.inst
-		return
-	}
+func (b *onceBind[From, To]) deps() ([]dependency, error) { return fieldDeps(b.typeTo) }
 
-	value, err = alloc(b.typeTo)
-	init = true
-	b.inst = value
-	b.done = true
+func (b *onceBind[From, To]) instance(bs *bindings) *instance {
+	return bs.owner(b).onces.instance(b)
+}
 
+func (b *onceBind[From, To]) solve(bs *bindings, r *resolution) (value reflect.Value, init bool, err error) {
+	// Once bindings are global instances of the bindings they are
+	// configured in. Therefore they must be solved there as well.
+	owner := bs.owner(b)
+	value, err = owner.onces.instance(b).get(owner, r, b.typeTo)
 	return
 }
 
@@ -232,3 +445,7 @@ func (b *onceBind[From, To]) For(k string) Binding {
 	b.key = k
 	return b
 }
+
+func (b *onceBind[From, To]) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
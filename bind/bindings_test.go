@@ -2,6 +2,9 @@ package bind_test
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/joa/goety/bind"
@@ -39,3 +42,68 @@ func TestOnce(t *testing.T) {
 		t.Error("onceInst initializer called more than once")
 	}
 }
+
+type lazyInst struct {
+	Name string `bind:"name"`
+}
+
+var lazyCounter int32
+
+func (li *lazyInst) InitAfter() (err error) {
+	atomic.AddInt32(&lazyCounter, 1)
+	return
+}
+
+func TestOnceLazy(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(), bind.OnceLazy[*lazyInst]())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if atomic.LoadInt32(&lazyCounter) != 0 {
+		t.Error("lazyInst was eagerly initialized")
+	}
+
+	// the binding is broken until "name" is bound
+	if _, err = bind.TryGet[*lazyInst](ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %s", err)
+	}
+
+	ctx, err = bind.Configure(context.Background(),
+		bind.OnceLazy[*lazyInst](),
+		bind.String("name").For("name"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg    sync.WaitGroup
+		insts = make([]*lazyInst, 8)
+	)
+
+	for i := range insts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			insts[i] = bind.Get[*lazyInst](ctx)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, inst := range insts {
+		if inst != insts[0] {
+			t.Fatal("two different instances of once")
+		}
+	}
+
+	if insts[0].Name != "name" {
+		t.Errorf("expected name, got %s", insts[0].Name)
+	}
+
+	if n := atomic.LoadInt32(&lazyCounter); n != 1 {
+		t.Errorf("expected one initialization, got %d", n)
+	}
+}
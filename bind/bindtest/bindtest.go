@@ -0,0 +1,283 @@
+// Package bindtest offers a test harness for code that uses package bind.
+//
+// Context configures a context for a test from production bindings,
+// overrides and fakes. All resolutions within that context are recorded
+// and can be asserted with Resolutions and Resolved. When the test
+// finishes the context is shut down and every override that has never
+// been resolved is reported as an error.
+//
+// Fakes are fallbacks for interfaces that aren't bound by the production
+// bindings. They are written by hand and can embed Recorder to record
+// their calls.
+//
+// Unbound interfaces are not mocked automatically. Go can't create types
+// with methods at runtime, reflect.StructOf doesn't promote the methods
+// of embedded interfaces, hence there is no way to implement an arbitrary
+// interface without generated code. Resolving an interface without a
+// production binding, override or fake fails with bind.ErrNoSuchBinding.
+//
+// Example
+//
+//  type fakeMailer struct {
+//    bindtest.Recorder
+//  }
+//
+//  func (m *fakeMailer) Send(to string) error {
+//    m.Record("Send", to)
+//    return nil
+//  }
+//
+//  func TestSignup(t *testing.T) {
+//    mailer := &fakeMailer{}
+//    ctx := bindtest.Context(t,
+//      app.Module,
+//      bindtest.Fake[Mailer](mailer),
+//      bindtest.Override(bind.Instance[Clock](fixedClock)))
+//
+//    bind.Get[*Signup](ctx).Run()
+//
+//    if mailer.Called("Send") != 1 {
+//      t.Error("expected a mail")
+//    }
+//  }
+package bindtest
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type contextKey string
+
+const harnessKey contextKey = "github.com/joa/goety/bind/bindtest"
+
+// Context configures and returns a context for the test t.
+//
+// The bindings are configured like with bind.Configure. Bindings created
+// with Override replace the production bindings of the same type and key
+// regardless of their order, bindings created with Fake are only used if
+// there is no other binding for their type. Both may be part of modules.
+// The test fails immediately if the configuration fails.
+//
+// The context is shut down with t.Cleanup. Overrides that haven't been
+// resolved by then are reported with t.Errorf.
+func Context(t testing.TB, bindings ...bind.Binding) context.Context {
+	t.Helper()
+
+	h := &harness{}
+	m := &markers{modules: make(map[*bind.Module]*bind.Module)}
+	rest := m.unwrap(bindings)
+
+	// Overrides are configured last, so the order of the bindings
+	// doesn't matter.
+	for _, o := range m.overrides {
+		h.overrides = append(h.overrides, o)
+		rest = append(rest, bind.Override(o))
+	}
+
+	// Fakes are configured in a parent context, hence any other
+	// binding for the same type shadows them, even in Strict mode.
+	fakes := append(m.fakes, bind.OnResolve(h.record), bind.Fallback())
+	base, err := bind.Configure(context.WithValue(context.Background(), harnessKey, h), fakes...)
+
+	if err != nil {
+		t.Fatalf("bindtest: can't configure fakes: %s", err)
+	}
+
+	ctx, err := bind.Configure(base, rest...)
+
+	if err != nil {
+		_ = bind.Shutdown(base)
+		t.Fatalf("bindtest: can't configure bindings: %s", err)
+	}
+
+	t.Cleanup(func() {
+		h.verify(t, ctx)
+
+		if err := bind.Shutdown(ctx); err != nil {
+			t.Errorf("bindtest: can't shut down: %s", err)
+		}
+
+		if err := bind.Shutdown(base); err != nil {
+			t.Errorf("bindtest: can't shut down fakes: %s", err)
+		}
+	})
+
+	return ctx
+}
+
+// Override - Replace the production binding of the same type and key.
+//
+// The test fails if the override is never resolved.
+func Override(b bind.Binding) bind.Binding {
+	return &overrideBinding{Binding: b}
+}
+
+// Fake - Bind fake for T unless there is another binding for T.
+func Fake[T any](fake T) bind.Binding {
+	return &fakeBinding{Binding: bind.Instance[T](fake)}
+}
+
+// Resolutions returns all resolutions within a context created by Context
+// in the order in which they have been completed.
+//
+// Nested dependencies are completed before the bindings that depend on them.
+func Resolutions(ctx context.Context) []bind.Event {
+	h := harnessOf(ctx)
+
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	return append([]bind.Event(nil), h.events...)
+}
+
+// Resolved returns how often T has been resolved for any key within a
+// context created by Context.
+func Resolved[T any](ctx context.Context) (n int) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	for _, e := range Resolutions(ctx) {
+		if e.Type == t {
+			n++
+		}
+	}
+
+	return
+}
+
+// overrideBinding marks a binding as override.
+type overrideBinding struct {
+	bind.Binding
+}
+
+func (b *overrideBinding) For(k string) bind.Binding {
+	b.Binding = b.Binding.For(k)
+	return b
+}
+
+func (b *overrideBinding) As(q any) bind.Binding {
+	b.Binding = b.Binding.As(q)
+	return b
+}
+
+// fakeBinding marks a binding as fake.
+type fakeBinding struct {
+	bind.Binding
+}
+
+func (b *fakeBinding) For(k string) bind.Binding {
+	b.Binding = b.Binding.For(k)
+	return b
+}
+
+func (b *fakeBinding) As(q any) bind.Binding {
+	b.Binding = b.Binding.As(q)
+	return b
+}
+
+// markers collects the bindings marked as fake or override.
+type markers struct {
+	fakes     []bind.Binding
+	overrides []bind.Binding
+	modules   map[*bind.Module]*bind.Module // modules without markers by module
+}
+
+// unwrap the markers of bindings and of the modules among them and
+// return all other bindings.
+func (m *markers) unwrap(bindings []bind.Binding) (rest []bind.Binding) {
+	for _, b := range bindings {
+		switch b := b.(type) {
+		case *fakeBinding:
+			m.fakes = append(m.fakes, b.Binding)
+		case *overrideBinding:
+			m.overrides = append(m.overrides, b.Binding)
+		case *bind.Module:
+			rest = append(rest, m.module(b))
+		default:
+			rest = append(rest, b)
+		}
+	}
+
+	return
+}
+
+// module returns mod or a copy of it without markers if it has any.
+//
+// Each module is unwrapped once, so a module that is included multiple
+// times is still installed only once.
+func (m *markers) module(mod *bind.Module) *bind.Module {
+	if res, ok := m.modules[mod]; ok {
+		return res
+	}
+
+	m.modules[mod] = mod
+	bindings := mod.Bindings()
+	rest := m.unwrap(bindings)
+	changed := len(rest) != len(bindings)
+
+	for i := 0; i < len(rest) && !changed; i++ {
+		changed = rest[i] != bindings[i]
+	}
+
+	if changed {
+		m.modules[mod] = bind.NewModule(mod.Name(), rest...)
+	}
+
+	return m.modules[mod]
+}
+
+// harness records the resolutions of a test context.
+type harness struct {
+	mut       sync.Mutex
+	events    []bind.Event
+	overrides []bind.Binding
+}
+
+func (h *harness) record(_ context.Context, e bind.Event) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	h.events = append(h.events, e)
+}
+
+// verify that all overrides have been resolved in ctx.
+func (h *harness) verify(t testing.TB, ctx context.Context) {
+	t.Helper()
+
+	used := make(map[bind.Binding]bool)
+
+	for _, e := range Resolutions(ctx) {
+		used[e.Binding] = true
+		used[e.Concrete] = true
+	}
+
+	d, err := bind.Describe(ctx)
+
+	if err != nil {
+		t.Errorf("bindtest: can't describe bindings: %s", err)
+		return
+	}
+
+	for _, b := range d.Bindings {
+		for _, o := range h.overrides {
+			if b.Binding == o && !used[o] {
+				t.Errorf("bindtest: override %s for %q hasn't been used", b.Type, b.Scope)
+			}
+		}
+	}
+}
+
+// harnessOf ctx or panic if ctx hasn't been created by Context.
+func harnessOf(ctx context.Context) *harness {
+	h, ok := ctx.Value(harnessKey).(*harness)
+
+	if !ok {
+		panic("bindtest: context hasn't been created by bindtest.Context")
+	}
+
+	return h
+}
@@ -0,0 +1,175 @@
+package bindtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joa/goety/bind"
+	"github.com/joa/goety/bind/bindtest"
+)
+
+type Mailer interface {
+	Send(to string) error
+}
+
+type Clock interface {
+	Now() int
+}
+
+type smtpMailer struct{}
+
+func (m *smtpMailer) Send(string) error { return nil }
+
+type systemClock struct{}
+
+func (c *systemClock) Now() int { return 0 }
+
+type fakeMailer struct {
+	bindtest.Recorder
+}
+
+func (m *fakeMailer) Send(to string) error {
+	m.Record("Send", to)
+	return nil
+}
+
+type fixedClock int
+
+func (c fixedClock) Now() int { return int(c) }
+
+type Signup struct {
+	Mailer Mailer `bind:"-"`
+	Clock  Clock  `bind:"-"`
+}
+
+var module = bind.NewModule("app",
+	bind.Implementation[Clock, *systemClock](),
+	bind.Type[*Signup]())
+
+// recordingT records errors and cleanups instead of failing the test.
+type recordingT struct {
+	*testing.T
+	errors   []string
+	cleanups []func()
+}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordingT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *recordingT) cleanup() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestContext(t *testing.T) {
+	mailer := &fakeMailer{}
+	rt := &recordingT{T: t}
+	ctx := bindtest.Context(rt,
+		module,
+		bindtest.Fake[Mailer](mailer),
+		bindtest.Fake[Clock](fixedClock(1)),
+		bindtest.Override(bind.Instance[Clock](fixedClock(42))))
+
+	signup := bind.Get[*Signup](ctx)
+
+	if signup.Clock.Now() != 42 {
+		t.Errorf("expected the override, got %d", signup.Clock.Now())
+	}
+
+	if err := signup.Mailer.Send("a@example.com"); err != nil || mailer.Called("Send") != 1 {
+		t.Errorf("expected the fake to record the call, got %v", mailer.Calls())
+	}
+
+	if n := bindtest.Resolved[Clock](ctx); n != 1 {
+		t.Errorf("expected Clock to be resolved once, got %d", n)
+	}
+
+	if n := len(bindtest.Resolutions(ctx)); n != 3 {
+		t.Errorf("expected 3 resolutions, got %d", n)
+	}
+
+	rt.cleanup()
+
+	if len(rt.errors) != 0 {
+		t.Errorf("expected no errors, got %v", rt.errors)
+	}
+}
+
+func TestContext_UnusedOverride(t *testing.T) {
+	rt := &recordingT{T: t}
+	ctx := bindtest.Context(rt,
+		module,
+		bindtest.Override(bind.Implementation[Mailer, *smtpMailer]()),
+		bindtest.Override(bind.Instance[Clock](fixedClock(42))))
+
+	bind.Get[Clock](ctx)
+	rt.cleanup()
+
+	if len(rt.errors) != 1 {
+		t.Fatalf("expected one error, got %v", rt.errors)
+	}
+
+	if exp := `bindtest: override bindtest_test.Mailer for "" hasn't been used`; rt.errors[0] != exp {
+		t.Errorf("expected %s, got %s", exp, rt.errors[0])
+	}
+}
+
+type primary struct{}
+
+func TestContext_Markers(t *testing.T) {
+	mailer := &fakeMailer{}
+	testModule := bind.NewModule("test",
+		bindtest.Fake[Mailer](mailer),
+		bindtest.Override(bind.Instance[string]("test")).For("host"))
+
+	rt := &recordingT{T: t}
+	ctx := bindtest.Context(rt,
+		bindtest.Override(bind.Instance[Clock](fixedClock(42))),
+		bindtest.Override(bind.Instance[string]("fake")).As(primary{}),
+		module,
+		testModule,
+		bind.NewModule("shared", testModule),
+		bind.Instance[string]("production").For("host"),
+		bind.Instance[string]("production").As(primary{}))
+
+	if v := bind.For[string](ctx, "host"); v != "test" {
+		t.Errorf("expected the override from the module, got %s", v)
+	}
+
+	if v := bind.For[string](ctx, bind.Qualifier[primary]()); v != "fake" {
+		t.Errorf("expected the qualified override, got %s", v)
+	}
+
+	if err := bind.Get[*Signup](ctx).Mailer.Send("a@example.com"); err != nil || mailer.Called("Send") != 1 {
+		t.Errorf("expected the fake from the module, got %v", mailer.Calls())
+	}
+
+	rt.cleanup()
+
+	if len(rt.errors) != 0 {
+		t.Errorf("expected no errors, got %v", rt.errors)
+	}
+}
+
+func TestContext_Strict(t *testing.T) {
+	rt := &recordingT{T: t}
+	ctx := bindtest.Context(rt,
+		bind.NewModule("strict", bind.Strict(), bind.Implementation[Mailer, *smtpMailer]()),
+		bindtest.Fake[Mailer](&fakeMailer{}))
+
+	if _, ok := bind.Get[Mailer](ctx).(*smtpMailer); !ok {
+		t.Error("expected the production binding to shadow the fake")
+	}
+
+	rt.cleanup()
+
+	if len(rt.errors) != 0 {
+		t.Errorf("expected no errors, got %v", rt.errors)
+	}
+}
@@ -0,0 +1,45 @@
+package bindtest
+
+import "sync"
+
+// Call of a method recorded by a Recorder.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Recorder records method calls of fakes.
+//
+// Recorder is embedded by fakes which call Record in each method.
+// It's safe for concurrent use.
+type Recorder struct {
+	mut   sync.Mutex
+	calls []Call
+}
+
+// Record a call of method with args.
+func (r *Recorder) Record(method string, args ...any) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls in order.
+func (r *Recorder) Calls() []Call {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// Called returns how often method has been called.
+func (r *Recorder) Called(method string) (n int) {
+	for _, c := range r.Calls() {
+		if c.Method == method {
+			n++
+		}
+	}
+
+	return
+}
@@ -20,6 +20,10 @@ func fromCtx(ctx context.Context) (b *bindings, loaded bool) {
 //
 // Errors are returned when there are duplicate bindings.
 //
+// Instances owned by the new context, like Once bindings, are disposed
+// with Shutdown or when ctx is done. If the configuration fails all
+// instances that have been created so far are disposed immediately.
+//
 // Example
 //
 //  bind.Configure(ctx,
@@ -34,11 +38,76 @@ func fromCtx(ctx context.Context) (b *bindings, loaded bool) {
 func Configure(ctx context.Context, bindings ...Binding) (context.Context, error) {
 	parent, _ := fromCtx(ctx)
 	b := newBindings(parent)
-	err := b.configure(bindings)
-	if err == nil {
-		ctx = context.WithValue(ctx, ctxKey, b)
+	configured := context.WithValue(ctx, ctxKey, b)
+	err := b.configure(configured, bindings)
+	if err != nil {
+		return ctx, errors.Join(err, b.shutdown())
+	}
+	if done := ctx.Done(); done != nil {
+		go func() {
+			<-done
+			_ = b.shutdown()
+		}()
+	}
+	return configured, nil
+}
+
+// Shutdown disposes all instances owned by the bindings of ctx.
+//
+// Instances are disposed in reverse order of their creation, hence an
+// instance is always disposed before its dependencies. Instances that
+// implement the Disposer interface are disposed, instances that implement
+// io.Closer are closed.
+//
+// Only instances of the bindings that have been created by the Configure
+// call which returned ctx are disposed, parent contexts are unaffected.
+// Errors of all instances are collected and returned at once.
+//
+// Shutdown is also triggered when the context passed to Configure is done.
+// Errors can't be observed in that case.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.ImplementationOnce[Database, *sqlDBImpl]()) // *sqlDBImpl implements io.Closer
+//
+//  defer bind.Shutdown(ctx) // closes the *sqlDBImpl instance
+func Shutdown(ctx context.Context) error {
+	b, loaded := fromCtx(ctx)
+
+	if !loaded {
+		return ErrContextWithoutBindings
+	}
+
+	return b.shutdown()
+}
+
+// Validate all bindings of a context.
+//
+// Validate walks every binding that is visible in ctx and checks
+// recursively that all of its dependencies can be satisfied. This
+// includes fields with a bind tag as well as constructor parameters.
+//
+// All problems are reported at once with a *ValidationError that holds
+// a *ResolutionError for each problem. The returned error wraps
+// ErrNoSuchBinding for missing bindings or scopes and
+// ErrUnsatisfiedInterface for interfaces without an implementation.
+//
+// Example
+//
+//  ctx, err := bind.Configure(ctx, bindings...)
+//
+//  if err == nil {
+//    err = bind.Validate(ctx) // fail at startup instead of bind.Get
+//  }
+func Validate(ctx context.Context) error {
+	b, loaded := fromCtx(ctx)
+
+	if !loaded {
+		return ErrContextWithoutBindings
 	}
-	return ctx, err
+
+	return b.validate()
 }
 
 // New creates and returns an instance of T for U.
@@ -85,22 +154,29 @@ func TryNew[T any](ctx context.Context) (res T, err error) {
 		return
 	}
 
-	v, err := b.get(t, "") // new will always search without a scope
+	r := newResolution(ctx)
+	v, found, err := b.resolve(r, dependency{typ: t}) // new will always search without a scope
 
-	if errors.Is(err, ErrNoSuchBinding) {
-		v, err = alloc(t)
+	if err != nil {
+		return
+	}
 
-		if err != nil {
+	if !found {
+		start := r.now()
+
+		if v, err = alloc(t); err != nil {
+			err = resolutionError(err, t, "", nil)
+			r.failed(Event{Type: t, Err: err})
 			return
 		}
 
-		err = b.initialize(v.Type(), v)
+		r.instantiated(v, start)
 
-		if err != nil {
+		if err = b.initialize(r, v.Type(), v); err != nil {
+			err = resolutionError(err, t, "", nil)
+			r.failed(Event{Type: t, Err: err})
 			return
 		}
-	} else if err != nil {
-		return
 	}
 
 	res = unboxValue[T](t, v)
@@ -150,7 +226,7 @@ func TryFor[V any](ctx context.Context, key string) (res V, err error) {
 		return
 	}
 
-	v, err := b.get(t, key)
+	v, err := b.get(newResolution(ctx), dependency{typ: t, tagOptions: tagOptions{scope: key}})
 
 	if err != nil {
 		return
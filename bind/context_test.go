@@ -3,7 +3,9 @@ package bind_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/joa/goety/bind"
 )
@@ -132,3 +134,362 @@ func TestGetIfaceToIfaceSatisfied(t *testing.T) {
 		t.Errorf("expected no error, got %s", err)
 	}
 }
+
+type ctorService struct {
+	db   Iface
+	name string
+}
+
+func newCtorService(db Iface, name string) (*ctorService, error) {
+	return &ctorService{db: db, name: name}, nil
+}
+
+func TestConstructor(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Instance[Iface](&Impl{"Field"}),
+		bind.String("name"),
+		bind.Constructor[*ctorService](newCtorService))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	res, err := bind.TryGet[*ctorService](ctx)
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := res.db.Meth(); act != "Impl-Field" {
+		t.Errorf("expected Impl-Field, got %s", act)
+	}
+
+	if res.name != "name" {
+		t.Errorf("expected name, got %s", res.name)
+	}
+}
+
+type ctorConfig struct {
+	Name string `bind:"name"`
+}
+
+func TestConstructorValueParameter(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("config").For("name"),
+		bind.Type[ctorConfig](),
+		bind.Constructor[*ctorService](func(cfg ctorConfig) *ctorService {
+			return &ctorService{name: cfg.Name}
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if res, err := bind.TryGet[*ctorService](ctx); err != nil || res.name != "config" {
+		t.Errorf("expected the injected config, got %v", err)
+	}
+}
+
+func TestConstructorError(t *testing.T) {
+	errCtor := errors.New("ctor")
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Constructor[Iface](func() (*Impl, error) { return nil, errCtor }))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryGet[Iface](ctx); !errors.Is(err, errCtor) {
+		t.Errorf("expected errCtor, got %s", err)
+	}
+
+	ctx, err = bind.Configure(context.Background(),
+		bind.Constructor[*ctorService](newCtorService))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryGet[*ctorService](ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %s", err)
+	}
+}
+
+func TestConstructorInvalid(t *testing.T) {
+	for _, fn := range []any{
+		nil,
+		"not a function",
+		func() {},
+		func() (*Impl, string) { return nil, "" },
+		func(...string) *Impl { return nil },
+		func() *ctorService { return nil },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic for %T", fn)
+				}
+			}()
+			bind.Constructor[Iface](fn)
+		}()
+	}
+}
+
+type cycleA struct {
+	B *cycleB `bind:"-"`
+}
+
+type cycleB struct {
+	Parent *cycleA `bind:"-"`
+}
+
+func TestCycle(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Type[*cycleA](),
+		bind.Type[*cycleB]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	_, err = bind.TryGet[*cycleA](ctx)
+
+	if !errors.Is(err, bind.ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %s", err)
+	}
+
+	exp := "dependency cycle: *bind_test.cycleA -> *bind_test.cycleB (field cycleA.B) -> *bind_test.cycleA (field cycleB.Parent)"
+
+	if err.Error() != exp {
+		t.Errorf("expected %s, got %s", exp, err)
+	}
+
+	if _, err = bind.TryNew[*cycleB](ctx); !errors.Is(err, bind.ErrCycle) {
+		t.Errorf("expected ErrCycle, got %s", err)
+	}
+
+	if err = bind.Validate(ctx); !errors.Is(err, bind.ErrCycle) {
+		t.Errorf("expected ErrCycle, got %s", err)
+	}
+}
+
+func TestCycleOnce(t *testing.T) {
+	_, err := bind.Configure(context.Background(),
+		bind.Once[*cycleA](),
+		bind.Type[*cycleB]())
+
+	if !errors.Is(err, bind.ErrCycle) {
+		t.Errorf("expected ErrCycle, got %s", err)
+	}
+}
+
+func TestProviderCtx(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("root").For("name"),
+		bind.ProviderCtx[*Impl](func(ctx context.Context) (*Impl, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			name, err := bind.TryFor[string](ctx, "name")
+			return &Impl{name}, err
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := bind.Get[*Impl](ctx).Field; act != "root" {
+		t.Errorf("expected root, got %s", act)
+	}
+
+	// the provider sees the context in which the resolution happens
+	child, err := bind.Configure(ctx, bind.String("child").For("name"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := bind.Get[*Impl](child).Field; act != "child" {
+		t.Errorf("expected child, got %s", act)
+	}
+
+	cancelled, cancel := context.WithCancel(child)
+	cancel()
+
+	if _, err = bind.TryGet[*Impl](cancelled); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %s", err)
+	}
+}
+
+type providerP struct{}
+
+type providerQ struct{}
+
+func TestProviderCtx_Cycle(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.ProviderCtx[*providerP](func(ctx context.Context) (*providerP, error) {
+			_, err := bind.TryGet[*providerQ](ctx)
+			return &providerP{}, err
+		}),
+		bind.ProviderCtx[*providerQ](func(ctx context.Context) (*providerQ, error) {
+			_, err := bind.TryGet[*providerP](ctx)
+			return &providerQ{}, err
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := bind.TryGet[*providerP](ctx)
+		done <- err
+	}()
+
+	select {
+	case err = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected ErrCycle, got an endless recursion")
+		return
+	}
+
+	if !errors.Is(err, bind.ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %v", err)
+	}
+
+	if exp := "*bind_test.providerP -> *bind_test.providerQ -> *bind_test.providerP"; !strings.Contains(err.Error(), exp) {
+		t.Errorf("expected %s, got %s", exp, err)
+	}
+}
+
+func TestConstructorCtx(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("name").For("name"),
+		bind.Constructor[*ctorService](func(ctx context.Context, db Iface) *ctorService {
+			return &ctorService{db: db, name: bind.For[string](ctx, "name")}
+		}),
+		bind.Instance[Iface](&Impl{"Field"}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	if res := bind.Get[*ctorService](ctx); res.name != "name" {
+		t.Errorf("expected name, got %s", res.name)
+	}
+}
+
+type tagOptionsT struct {
+	Cache   Iface         `bind:"cache,optional"`
+	Port    int           `bind:"port,default=5432"`
+	Host    string        `bind:"host,default=localhost"`
+	Timeout time.Duration `bind:"timeout,default=1s"`
+}
+
+func TestTagOptions(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(), bind.String("example.com").For("host"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	res := bind.New[*tagOptionsT](ctx)
+
+	if res.Cache != nil || res.Port != 5432 || res.Host != "example.com" || res.Timeout != time.Second {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	// optional fields are still injected if there is a binding
+	ctx, err = bind.Configure(ctx,
+		bind.Implementation[Iface, *Impl]().For("cache"),
+		bind.Int(80).For("port"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if res = bind.New[*tagOptionsT](ctx); res.Cache == nil || res.Port != 80 {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestTagOptionsErrors(t *testing.T) {
+	type invalid struct {
+		Port int `bind:"port,defualt=1"`
+	}
+
+	type conversion struct {
+		Port int `bind:"port,default=abc"`
+	}
+
+	type unsatisfied struct {
+		Cache IfaceA `bind:"-,optional"`
+	}
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Type[*invalid](),
+		bind.Type[*conversion](),
+		bind.Implementation[IfaceA, IfaceB]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryNew[*invalid](ctx); !errors.Is(err, bind.ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag, got %s", err)
+	} else if msg := err.Error(); !strings.Contains(msg, `"defualt=1"`) || !strings.Contains(msg, "invalid.Port") {
+		t.Errorf("expected option and field in error, got %s", msg)
+	}
+
+	if _, err = bind.TryNew[*conversion](ctx); !errors.Is(err, bind.ErrConversion) {
+		t.Errorf("expected ErrConversion, got %s", err)
+	}
+
+	// optional fields fail if the binding exists but can't be solved
+	if _, err = bind.TryNew[*unsatisfied](ctx); !errors.Is(err, bind.ErrUnsatisfiedInterface) {
+		t.Errorf("expected ErrUnsatisfiedInterface, got %s", err)
+	}
+
+	err = bind.Validate(ctx)
+
+	if !errors.Is(err, bind.ErrInvalidTag) || !errors.Is(err, bind.ErrConversion) {
+		t.Errorf("expected ErrInvalidTag and ErrConversion, got %s", err)
+	}
+
+	var ve *bind.ValidationError
+
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+		return
+	}
+
+	for _, re := range ve.Errors {
+		if errors.Is(re, bind.ErrConversion) && (len(re.Path) != 1 || re.Path[0] != "conversion.Port") {
+			t.Errorf("expected path [conversion.Port], got %v", re.Path)
+		}
+	}
+}
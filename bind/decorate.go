@@ -0,0 +1,169 @@
+package bind
+
+import (
+	"context"
+	"reflect"
+)
+
+// Decorate - Wrap resolved instances of T with f.
+//
+// The function f receives the context in which T is resolved and the
+// instance that has been resolved. The result of f is used instead.
+// Decorators don't change the binding of T itself and apply to all
+// bindings of T for the same scope, given with For.
+//
+// Decorators are stackable. Decorators of parent contexts are applied
+// first, followed by the decorators of the child contexts. Within a
+// context they are applied in the order in which they are configured.
+//
+// Instances of Once and Scoped bindings are decorated once per context
+// that configures decorators, hence every resolution in that context
+// returns the same decorated instance. Other bindings are decorated with
+// every resolution.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.ImplementationOnce[Database, *sqlDBImpl]())
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.Decorate[Database](func(ctx context.Context, db Database) (Database, error) {
+//      return &metricsDB{inner: db}, nil
+//    }))
+//
+//  db := bind.Get[Database](ctx) // *metricsDB wrapping *sqlDBImpl
+func Decorate[T any](f func(ctx context.Context, inner T) (T, error)) Binding {
+	return &decorateBind[T]{f: f}
+}
+
+// decorator of values.
+type decorator interface {
+	// decorate the value v in ctx.
+	decorate(ctx context.Context, v reflect.Value) (reflect.Value, error)
+}
+
+// decorateBind is an option that decorates the instances of T.
+type decorateBind[T any] struct {
+	key string
+	f   func(context.Context, T) (T, error)
+}
+
+func (b *decorateBind[T]) typ() reflect.Type { return typeOf[T]() }
+func (b *decorateBind[T]) scope() string     { return b.key }
+func (b *decorateBind[T]) eager() bool       { return false }
+
+func (b *decorateBind[T]) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	panic("bind: can't solve an option")
+}
+
+func (b *decorateBind[T]) For(k string) Binding {
+	b.key = normalizeScope(k)
+	return b
+}
+
+func (b *decorateBind[T]) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
+
+func (b *decorateBind[T]) configure(bs *bindings) (err error) {
+	if bs.decorators == nil {
+		bs.decorators = make(moduleDecorators)
+	}
+
+	typeScope, loaded := bs.decorators[b.typ()]
+
+	if !loaded {
+		typeScope = make(typeDecorators)
+		bs.decorators[b.typ()] = typeScope
+	}
+
+	typeScope[b.key] = append(typeScope[b.key], b)
+
+	return
+}
+
+func (b *decorateBind[T]) decorate(ctx context.Context, v reflect.Value) (res reflect.Value, err error) {
+	t := typeOf[T]()
+	inner := unboxValue[T](t, v)
+	outer, err := b.f(ctx, inner)
+	res = reflect.ValueOf(&outer).Elem()
+	return
+}
+
+type typeDecorators map[string][]decorator
+
+type moduleDecorators map[reflect.Type]typeDecorators
+
+// decorate the value v of type t and scope k with all decorators of bs and its parents.
+//
+// If v is the shared instance of a Once or Scoped binding b, it is decorated
+// only once per layer whose decorators apply, so that all resolutions get
+// the same decorated value. The result is cached with the instance.
+func (bs *bindings) decorate(r *resolution, b Binding, t reflect.Type, k string, v reflect.Value) (res reflect.Value, err error) {
+	decorators, layer := bs.decoratorsOf(t, k)
+
+	if len(decorators) == 0 {
+		return v, nil
+	}
+
+	var inst *instance
+
+	if i, ok := b.(bindingInstance); ok {
+		inst = i.instance(bs)
+	}
+
+	key := decoratedKey{layer: layer, t: t, k: k}
+
+	if inst != nil {
+		if res, ok := inst.decoratedValue(key); ok {
+			return res, nil
+		}
+	}
+
+	res = v
+
+	for _, d := range decorators {
+		if res, err = d.decorate(r.context(), res); err != nil {
+			return
+		}
+	}
+
+	if inst != nil {
+		// concurrent resolutions may have decorated the instance as well,
+		// the value that has been stored first wins
+		res = inst.storeDecorated(key, res)
+	}
+
+	return
+}
+
+// decoratorsOf type t and scope k in bs and its parents in the order in
+// which they are applied, along with the nearest layer that has decorators.
+func (bs *bindings) decoratorsOf(t reflect.Type, k string) (decorators []decorator, layer *bindings) {
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+		own := bb.decorators[t][k]
+		bb.mut.RUnlock()
+
+		if len(own) > 0 && layer == nil {
+			layer = bb
+		}
+
+		decorators = append(append([]decorator(nil), own...), decorators...)
+	}
+
+	return
+}
+
+// bindingInstance is implemented by bindings that are solved to a shared instance.
+type bindingInstance interface {
+	// instance of the binding that is shared in bs, nil if there is none.
+	instance(bs *bindings) *instance
+}
+
+// decoratedKey of an instance that has been decorated.
+type decoratedKey struct {
+	layer *bindings // nearest layer with decorators
+	t     reflect.Type
+	k     string
+}
@@ -0,0 +1,129 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type decorated struct {
+	inner Iface
+	tag   string
+}
+
+func (d *decorated) Meth() string { return d.tag + "(" + d.inner.Meth() + ")" }
+
+func decorateWith(tag string) func(context.Context, Iface) (Iface, error) {
+	return func(_ context.Context, inner Iface) (Iface, error) {
+		return &decorated{inner: inner, tag: tag}, nil
+	}
+}
+
+func TestDecorate(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.ImplementationOnce[Iface, *Impl](),
+		bind.Decorate[Iface](decorateWith("a")),
+		bind.Decorate[Iface](decorateWith("b")),
+		bind.Decorate[Iface](decorateWith("x")).For("x"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := bind.Get[Iface](ctx).Meth(); act != "b(a(Impl-))" {
+		t.Errorf("expected b(a(Impl-)), got %s", act)
+	}
+
+	// the binding itself is unchanged
+	if act := bind.New[*Impl](ctx).Meth(); act != "Impl-" {
+		t.Errorf("expected Impl-, got %s", act)
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.Decorate[Iface](decorateWith("c")))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := bind.Get[Iface](child).Meth(); act != "c(b(a(Impl-)))" {
+		t.Errorf("expected c(b(a(Impl-))), got %s", act)
+	}
+
+	if act := bind.Get[Iface](ctx).Meth(); act != "b(a(Impl-))" {
+		t.Errorf("expected b(a(Impl-)), got %s", act)
+	}
+}
+
+func TestDecorateError(t *testing.T) {
+	errDecorate := errors.New("decorate")
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Implementation[Iface, *Impl](),
+		bind.Decorate[Iface](func(context.Context, Iface) (Iface, error) {
+			return nil, errDecorate
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryGet[Iface](ctx); !errors.Is(err, errDecorate) {
+		t.Errorf("expected errDecorate, got %s", err)
+	}
+}
+
+func TestDecorateOnce(t *testing.T) {
+	calls := 0
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.ImplementationOnce[Iface, *Impl](),
+		bind.Decorate[Iface](func(_ context.Context, inner Iface) (Iface, error) {
+			calls++
+			return &decorated{inner: inner, tag: "a"}, nil
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if a, b := bind.Get[Iface](ctx), bind.Get[Iface](ctx); a != b || calls != 1 {
+		t.Errorf("expected the same decorated instance, got %p and %p with %d calls", a, b, calls)
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.Decorate[Iface](func(_ context.Context, inner Iface) (Iface, error) {
+			return &decorated{inner: inner, tag: "b"}, nil
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if a, b := bind.Get[Iface](child), bind.Get[Iface](child); a != b || a.Meth() != "b(a(Impl-))" {
+		t.Errorf("expected the same decorated instance, got %s and %s", a.Meth(), b.Meth())
+	}
+
+	if calls != 2 {
+		t.Errorf("expected the decorators of the parent to run again for the child, got %d calls", calls)
+	}
+
+	// contexts without own decorators share the decorated instance of their parent
+	plain, err := bind.Configure(ctx, bind.String("x").For("unrelated"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if bind.Get[Iface](plain) != bind.Get[Iface](ctx) || calls != 2 {
+		t.Errorf("expected the decorated instance of the parent, got %d calls", calls)
+	}
+}
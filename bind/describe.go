@@ -0,0 +1,366 @@
+package bind
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind of binding.
+type Kind string
+
+const (
+	KindType        Kind = "type"        // bound with Type or Implementation
+	KindInstance    Kind = "instance"    // bound with Instance
+	KindProvider    Kind = "provider"    // bound with Provider or ProviderCtx
+	KindConstructor Kind = "constructor" // bound with Constructor
+	KindOnce        Kind = "once"        // bound with Once or one of its variants
+	KindScoped      Kind = "scoped"      // bound with Scoped or ImplementationScoped
+	KindMulti       Kind = "multi"       // contributed with Multi
+	KindGeneric     Kind = "generic"     // bound with Generic
+)
+
+// bindingKind is implemented by bindings that can be described.
+type bindingKind interface {
+	// kind of this binding.
+	kind() Kind
+}
+
+// Description of the bindings of a context and its parents.
+type Description struct {
+	// Bindings ordered by layer, type and scope.
+	Bindings []BindingInfo
+}
+
+// BindingInfo describes a binding.
+type BindingInfo struct {
+	Binding      Binding          // the binding itself
+	Type         reflect.Type     // type that is bound
+	Target       reflect.Type     // type that is created for Type, if known
+	Scope        string           // key of the binding, given with For or As
+	Kind         Kind             // kind of the binding
+	Module       string           // name of the module of the binding, if any
+	Layer        int              // 0 for the described context, 1 for its parent, ...
+	Overridden   bool             // shadowed by a binding of a child context
+	Dependencies []DependencyInfo // dependencies of the binding
+}
+
+// DependencyInfo describes a dependency of a binding.
+type DependencyInfo struct {
+	Type     reflect.Type // type that is depended on
+	Scope    string       // key of the dependency
+	Field    string       // struct field of the dependency, if any
+	Optional bool         // the dependency is optional or has a default
+	Handle   bool         // the dependency is resolved by Lazy or Factory
+	Targets  []int        // indices of the bindings that satisfy the dependency
+}
+
+// Describe the bindings of a context.
+//
+// The description contains all bindings of ctx and its parents including
+// those that are overridden by child contexts. Dependencies are derived
+// from struct tags and constructor parameters.
+//
+// Use String or DOT to render the description.
+//
+// Example
+//
+//  d, _ := bind.Describe(ctx)
+//  fmt.Println(d)       // text
+//  fmt.Println(d.DOT()) // Graphviz
+func Describe(ctx context.Context) (res Description, err error) {
+	b, loaded := fromCtx(ctx)
+
+	if !loaded {
+		err = ErrContextWithoutBindings
+		return
+	}
+
+	res = b.describe()
+
+	return
+}
+
+// describe the bindings of bs and its parents.
+func (bs *bindings) describe() (res Description) {
+	var (
+		all     []Binding
+		indices = make(map[Binding]int)
+		deep    = bs.deep()
+	)
+
+	layer := 0
+
+	for bb := bs; bb != nil; bb = bb.parent {
+		var bindings []Binding
+
+		bb.mut.RLock()
+		modules := bb.modules
+
+		for _, typeScope := range bb.bindings {
+			for _, b := range typeScope {
+				bindings = append(bindings, b)
+			}
+		}
+
+		for _, contributions := range bb.multi {
+			for _, c := range contributions {
+				bindings = append(bindings, c)
+			}
+		}
+
+		for _, generics := range bb.generics {
+			for _, g := range generics {
+				bindings = append(bindings, g)
+			}
+		}
+
+		bb.mut.RUnlock()
+
+		sort.SliceStable(bindings, func(i, j int) bool {
+			ti, tj := bindings[i].typ().String(), bindings[j].typ().String()
+
+			if ti == tj {
+				return bindings[i].scope() < bindings[j].scope()
+			}
+
+			return ti < tj
+		})
+
+		for _, b := range bindings {
+			indices[b] = len(all)
+			all = append(all, b)
+			info := bs.describeBinding(b, layer)
+
+			if m := modules[b]; m != nil {
+				info.Module = m.name
+			}
+
+			res.Bindings = append(res.Bindings, info)
+		}
+
+		layer++
+	}
+
+	for i, b := range all {
+		if c, ok := b.(*multiBind); ok {
+			b = c.b
+		}
+
+		d, ok := bs.concrete(b).(bindingDeps)
+
+		if !ok {
+			continue
+		}
+
+		// errors are reported by Validate
+		deps, _ := d.deps()
+
+		for _, dep := range deps {
+			if dep.deep && !deep {
+				continue
+			}
+
+			info := DependencyInfo{
+				Type:     dep.typ,
+				Scope:    dep.scope,
+				Field:    dep.field,
+				Optional: dep.optional || dep.hasDefault,
+				Handle:   dep.handle != nil,
+			}
+
+			if g, found := bs.generic(dep.typ, dep.scope); found {
+				info.Targets = append(info.Targets, indices[g])
+			} else if next, found := findBinding(bs, dep.typ, dep.scope); found {
+				info.Targets = append(info.Targets, indices[next])
+			} else if dep.scope == "" {
+				for _, c := range bs.contributions(dep.typ) {
+					info.Targets = append(info.Targets, indices[c])
+				}
+			}
+
+			res.Bindings[i].Dependencies = append(res.Bindings[i].Dependencies, info)
+		}
+	}
+
+	return
+}
+
+// describeBinding b of the given layer.
+func (bs *bindings) describeBinding(b Binding, layer int) (res BindingInfo) {
+	res = BindingInfo{
+		Binding: b,
+		Type:    b.typ(),
+		Scope:   b.scope(),
+		Layer:   layer,
+	}
+
+	if k, ok := b.(bindingKind); ok {
+		res.Kind = k.kind()
+	}
+
+	if c, ok := b.(*multiBind); ok {
+		res.Overridden = true
+
+		for _, visible := range bs.contributions(reflect.SliceOf(c.typ())) {
+			res.Overridden = res.Overridden && visible != c
+		}
+
+		b = c.b
+	} else if g, ok := b.(*genericBind); ok {
+		res.Overridden = bs.shadowsGeneric(g)
+	} else {
+		found, _ := findBinding(bs, b.typ(), b.scope())
+		res.Overridden = found != b
+	}
+
+	if to, ok := b.(bindingTo); ok && to.typTo() != res.Type {
+		res.Target = to.typTo()
+	}
+
+	return
+}
+
+// String renders the description as text.
+//
+// Bindings are grouped by layer and followed by their dependencies.
+func (d Description) String() string {
+	var sb strings.Builder
+
+	for i, b := range d.Bindings {
+		if i == 0 || d.Bindings[i-1].Layer != b.Layer {
+			fmt.Fprintf(&sb, "layer %d\n", b.Layer)
+		}
+
+		fmt.Fprintf(&sb, "  %s (%s", b.label(), b.Kind)
+
+		if b.Module != "" {
+			fmt.Fprintf(&sb, ", module %s", b.Module)
+		}
+
+		if b.Overridden {
+			sb.WriteString(", overridden")
+		}
+
+		sb.WriteString(")\n")
+
+		for _, dep := range b.Dependencies {
+			fmt.Fprintf(&sb, "    %s", describeKey(dep.Type, dep.Scope))
+
+			var notes []string
+
+			if dep.Field != "" {
+				notes = append(notes, "field "+dep.Field)
+			}
+
+			if dep.Optional {
+				notes = append(notes, "optional")
+			}
+
+			if dep.Handle {
+				notes = append(notes, "handle")
+			}
+
+			if len(dep.Targets) == 0 {
+				notes = append(notes, "unbound")
+			}
+
+			if len(notes) > 0 {
+				fmt.Fprintf(&sb, " (%s)", strings.Join(notes, ", "))
+			}
+
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// DOT renders the dependency graph of the description in the Graphviz
+// DOT language.
+//
+// Overridden bindings are omitted. Dependencies on handles are dashed,
+// dependencies without a binding point to a red node.
+func (d Description) DOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph bindings {\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	unbound := make(map[string]string)
+
+	for i, b := range d.Bindings {
+		if b.Overridden {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "  n%d [label=%s];\n", i, strconv.Quote(b.label()+"\n"+string(b.Kind)))
+	}
+
+	for i, b := range d.Bindings {
+		if b.Overridden {
+			continue
+		}
+
+		for _, dep := range b.Dependencies {
+			attrs := ""
+
+			if dep.Field != "" {
+				attrs = "label=" + strconv.Quote(dep.Field)
+			}
+
+			if dep.Handle {
+				attrs = strings.TrimPrefix(attrs+", style=dashed", ", ")
+			}
+
+			if attrs != "" {
+				attrs = " [" + attrs + "]"
+			}
+
+			if len(dep.Targets) == 0 {
+				key := describeKey(dep.Type, dep.Scope)
+				id, ok := unbound[key]
+
+				if !ok {
+					id = fmt.Sprintf("u%d", len(unbound))
+					unbound[key] = id
+					fmt.Fprintf(&sb, "  %s [label=%s, color=red];\n", id, strconv.Quote(key))
+				}
+
+				fmt.Fprintf(&sb, "  n%d -> %s%s;\n", i, id, attrs)
+				continue
+			}
+
+			for _, t := range dep.Targets {
+				fmt.Fprintf(&sb, "  n%d -> n%d%s;\n", i, t, attrs)
+			}
+		}
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// label of the binding.
+func (b BindingInfo) label() string {
+	res := describeKey(b.Type, b.Scope)
+
+	if b.Target != nil {
+		res += " -> " + b.Target.String()
+	}
+
+	return res
+}
+
+// describeKey of type t and scope k.
+func describeKey(t reflect.Type, k string) string {
+	if k == "" {
+		return t.String()
+	}
+
+	return fmt.Sprintf(`%s for "%s"`, t, k)
+}
@@ -0,0 +1,105 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type describeRepository struct {
+	DB   bind.Lazy[string] `bind:"db"`
+	Port int               `bind:"port,optional"`
+}
+
+type describeService struct {
+	Repo *describeRepository `bind:"-"`
+}
+
+func TestDescribe(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Instance[string]("postgres").For("db"),
+		bind.Type[*describeRepository]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	ctx, err = bind.Configure(ctx,
+		bind.Instance[string]("mysql").For("db"),
+		bind.Once[*describeService]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	d, err := bind.Describe(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if len(d.Bindings) != 4 {
+		t.Fatalf("expected 4 bindings, got %d", len(d.Bindings))
+	}
+
+	var (
+		child, parent, repo bind.BindingInfo
+	)
+
+	for _, b := range d.Bindings {
+		switch {
+		case b.Type.String() == "string" && b.Layer == 0:
+			child = b
+		case b.Type.String() == "string" && b.Layer == 1:
+			parent = b
+		case b.Type.String() == "*bind_test.describeRepository":
+			repo = b
+		}
+	}
+
+	if child.Overridden || !parent.Overridden || child.Kind != bind.KindInstance || child.Scope != "db" {
+		t.Errorf("unexpected string bindings %+v and %+v", child, parent)
+	}
+
+	if repo.Kind != bind.KindType || repo.Layer != 1 || len(repo.Dependencies) != 2 {
+		t.Fatalf("unexpected repository binding %+v", repo)
+	}
+
+	db, port := repo.Dependencies[0], repo.Dependencies[1]
+
+	if !db.Handle || len(db.Targets) != 1 || d.Bindings[db.Targets[0]].Layer != 0 {
+		t.Errorf("expected handle for the child binding, got %+v", db)
+	}
+
+	if port.Field != "describeRepository.Port" || len(port.Targets) != 0 {
+		t.Errorf("expected unbound port, got %+v", port)
+	}
+
+	text := d.String()
+
+	for _, s := range []string{"layer 0", "layer 1", `string for "db" (instance, overridden)`, "(field describeRepository.Port, optional, unbound)"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("expected %q in\n%s", s, text)
+		}
+	}
+
+	dot := d.DOT()
+
+	for _, s := range []string{"digraph bindings {", "style=dashed", "color=red"} {
+		if !strings.Contains(dot, s) {
+			t.Errorf("expected %q in\n%s", s, dot)
+		}
+	}
+}
+
+func TestDescribe_WithoutBindings(t *testing.T) {
+	if _, err := bind.Describe(context.Background()); !errors.Is(err, bind.ErrContextWithoutBindings) {
+		t.Errorf("expected ErrContextWithoutBindings, got %v", err)
+	}
+}
@@ -1,10 +1,96 @@
 package bind
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 var (
 	ErrDuplicate              = errors.New("duplicate binding")     // this exact same binding already exists
 	ErrNoSuchBinding          = errors.New("no such binding")       // this binding doesn't exist (when resolving)
 	ErrContextWithoutBindings = errors.New("no bindings")           // there are no bindings for the context
 	ErrUnsatisfiedInterface   = errors.New("interface unsatisfied") // the interface isn't bound to a concrete instance
+	ErrCycle                  = errors.New("dependency cycle")      // the binding depends on itself (when resolving)
+	ErrScopeNotOpen           = errors.New("scope not open")        // no context opened the scope of a binding (when resolving)
+	ErrConversion             = errors.New("conversion failed")     // a configuration value can't be converted (when resolving)
+	ErrInvalidTag             = errors.New("invalid bind tag")      // the bind struct tag of a field is malformed
+	ErrShadowed               = errors.New("shadowed binding")      // a binding shadows a binding of a parent context without Override (strict mode)
+	ErrAmbiguous              = errors.New("ambiguous binding")     // more than one binding is assignable to an interface (auto-wire mode)
 )
+
+// ResolutionError is returned when a value can't be resolved.
+//
+// It wraps the cause of the failure, e.g. ErrNoSuchBinding, hence
+// errors.Is works with the sentinel errors above. Use errors.As to
+// inspect which type, key and field failed. A dependency cycle that
+// is detected during resolution has no path since its cause already
+// lists all bindings of the cycle.
+//
+// Example
+//
+//  var re *bind.ResolutionError
+//
+//  if _, err := bind.TryNew[*Service](ctx); errors.As(err, &re) {
+//    log.Printf("can't resolve %s for %v: %s", re.Type, re.Path, re.Err)
+//  }
+type ResolutionError struct {
+	Type  reflect.Type // type that failed to resolve
+	Scope string       // key of the binding, if any
+	Path  []string     // struct fields leading to the failure, starting with the outermost, if any
+	Err   error        // cause of the failure
+}
+
+// Error returns the cause followed by the path, if any.
+func (e *ResolutionError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s (%s)", e.Err, strings.Join(e.Path, " -> "))
+}
+
+// Unwrap returns the cause of the failure.
+func (e *ResolutionError) Unwrap() error { return e.Err }
+
+// resolutionError wraps err for type t and key k requested by path
+// unless err has been wrapped already.
+func resolutionError(err error, t reflect.Type, k string, path []string) error {
+	var re *ResolutionError
+
+	if err == nil || errors.As(err, &re) {
+		return err
+	}
+
+	return &ResolutionError{Type: t, Scope: k, Path: path, Err: err}
+}
+
+// ValidationError aggregates all problems found by Validate.
+//
+// It matches every error it aggregates with errors.Is and errors.As.
+type ValidationError struct {
+	Errors []*ResolutionError
+}
+
+// Error returns the messages of all errors separated by newlines.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns all aggregated errors.
+func (e *ValidationError) Unwrap() []error {
+	res := make([]error, len(e.Errors))
+
+	for i, err := range e.Errors {
+		res[i] = err
+	}
+
+	return res
+}
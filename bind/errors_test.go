@@ -0,0 +1,127 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type errorsRepo struct {
+	DSN string `bind:"dsn"`
+}
+
+type errorsService struct {
+	Repo *errorsRepo `bind:"-"`
+}
+
+type errorsHandler struct {
+	Service *errorsService `bind:"-"`
+}
+
+func TestResolutionError(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Type[*errorsRepo](),
+		bind.Type[*errorsService]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	_, err = bind.TryNew[*errorsHandler](ctx)
+
+	var re *bind.ResolutionError
+
+	if !errors.As(err, &re) {
+		t.Fatalf("expected a *ResolutionError, got %v", err)
+		return
+	}
+
+	if !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %v", err)
+	}
+
+	if re.Type != reflect.TypeOf("") || re.Scope != "dsn" {
+		t.Errorf(`expected string for "dsn", got %s for "%s"`, re.Type, re.Scope)
+	}
+
+	exp := []string{"errorsHandler.Service", "errorsService.Repo", "errorsRepo.DSN"}
+
+	if !reflect.DeepEqual(re.Path, exp) {
+		t.Errorf("expected path %v, got %v", exp, re.Path)
+	}
+
+	if msg := `no such binding: string for "dsn" (errorsHandler.Service -> errorsService.Repo -> errorsRepo.DSN)`; err.Error() != msg {
+		t.Errorf("expected %s, got %s", msg, err)
+	}
+}
+
+func TestResolutionError_Provider(t *testing.T) {
+	boom := errors.New("boom")
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Provider[*errorsRepo](func() (*errorsRepo, error) { return nil, boom }),
+		bind.Type[*errorsService]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	_, err = bind.TryGet[*errorsService](ctx)
+
+	var re *bind.ResolutionError
+
+	if !errors.As(err, &re) || !errors.Is(err, boom) {
+		t.Fatalf("expected a *ResolutionError caused by boom, got %v", err)
+		return
+	}
+
+	if re.Type != reflect.TypeOf(&errorsRepo{}) || !reflect.DeepEqual(re.Path, []string{"errorsService.Repo"}) {
+		t.Errorf("expected *errorsRepo for errorsService.Repo, got %s for %v", re.Type, re.Path)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Type[*errorsRepo](),
+		bind.Type[*errorsHandler]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	err = bind.Validate(ctx)
+
+	var ve *bind.ValidationError
+
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+		return
+	}
+
+	if len(ve.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %v", ve.Errors)
+		return
+	}
+
+	types := map[reflect.Type]bool{}
+
+	for _, re := range ve.Errors {
+		types[re.Type] = true
+	}
+
+	if !types[reflect.TypeOf("")] || !types[reflect.TypeOf(&errorsService{})] {
+		t.Errorf("expected missing string and *errorsService, got %v", ve.Errors)
+	}
+
+	var re *bind.ResolutionError
+
+	if !errors.As(err, &re) || !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected the aggregated errors to match, got %v", err)
+	}
+}
@@ -0,0 +1,326 @@
+package bind
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Generic - Bind all instantiations of the generic type of T with factory.
+//
+// T is any instantiation of the generic type, e.g. Cache[any, any] binds
+// all instantiations of Cache. Pointers are part of the family, so
+// *Repository[any] covers *Repository[User] but not Repository[User].
+//
+// The factory is called with the requested instantiation when there is
+// no exact binding for it in the context or its parents and returns the
+// binding to use. It may return nil to leave an instantiation unbound.
+// The factory is called at most once per context and instantiation, so
+// a Once binding yields one instance per instantiation. Concurrent
+// lookups of the same instantiation wait for the factory.
+//
+// Concrete generic types don't need a factory, see GenericType and
+// GenericOnce. Generic interfaces do: Go can't instantiate generic types
+// at runtime, hence there is no way to derive lruCache[string, *User]
+// from Cache[string, *User]. The factory has to know the instantiations
+// of the implementation that are in use, e.g. by switching over them.
+//
+// Example
+//
+//  bind.Configure(ctx,
+//    bind.Generic[Cache[any, any]](func(t reflect.Type) (bind.Binding, error) {
+//      switch t {
+//      case reflect.TypeOf((*Cache[string, *User])(nil)).Elem():
+//        return bind.ImplementationOnce[Cache[string, *User], *lruCache[string, *User]](), nil
+//      case reflect.TypeOf((*Cache[int, *Order])(nil)).Elem():
+//        return bind.ImplementationOnce[Cache[int, *Order], *lruCache[int, *Order]](), nil
+//      }
+//      return nil, nil
+//    }))
+//
+// This method panics if T isn't an instantiation of a generic type.
+func Generic[T any](factory func(t reflect.Type) (Binding, error)) Binding {
+	t := typeOf[T]()
+	family, ok := familyOf(t)
+
+	if !ok {
+		panic(fmt.Sprintf("bind: %s isn't an instantiation of a generic type", t))
+	}
+
+	return &genericBind{sample: t, family: family, factory: factory}
+}
+
+// GenericType - Bind all instantiations of the concrete generic type of T
+// to new instances of themselves.
+//
+// This is Type for every instantiation that is requested, e.g.
+// *Repository[any] resolves *Repository[User] to a new *Repository[User]
+// with its fields injected.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.GenericType[*Repository[any]]())
+//
+//  users := bind.Get[*Repository[User]](ctx)
+//
+// This method panics if T isn't an instantiation of a generic type or
+// if T is an interface type.
+func GenericType[T any]() Binding {
+	mustBeConcrete[T]()
+
+	return Generic[T](func(t reflect.Type) (Binding, error) {
+		return &typeBind[any, any]{typeFrom: t, typeTo: t}, nil
+	})
+}
+
+// GenericOnce - Bind all instantiations of the concrete generic type of T
+// exactly once per instantiation.
+//
+// Unlike Once bindings the instances are created when an instantiation is
+// requested for the first time, like OnceLazy, since the instantiations
+// aren't known when the context is configured.
+//
+// This method panics if T isn't an instantiation of a generic type or
+// if T is an interface type.
+func GenericOnce[T any]() Binding {
+	mustBeConcrete[T]()
+
+	return Generic[T](func(t reflect.Type) (Binding, error) {
+		return &onceBind[any, any]{lazy: true, typeFrom: t, typeTo: t}, nil
+	})
+}
+
+// mustBeConcrete panics if T is an interface type.
+func mustBeConcrete[T any]() {
+	if t := typeOf[T](); t.Kind() == reflect.Interface {
+		panic(fmt.Sprintf("bind: can't instantiate interface %s, use Generic instead", t))
+	}
+}
+
+// familyOf returns the name of the generic type of t including its
+// package and pointers, e.g. *example.com/pkg.Repository.
+//
+// The result is false if t isn't an instantiation of a generic type.
+func familyOf(t reflect.Type) (string, bool) {
+	pointers := 0
+
+	for t.Kind() == reflect.Pointer && t.Name() == "" {
+		t = t.Elem()
+		pointers++
+	}
+
+	name, _, generic := strings.Cut(t.Name(), "[")
+
+	if !generic {
+		return "", false
+	}
+
+	return strings.Repeat("*", pointers) + t.PkgPath() + "." + name, true
+}
+
+// genericBind represents the binding of a family of generic instantiations.
+type genericBind struct {
+	key     string
+	sample  reflect.Type // instantiation the binding was created with
+	family  string
+	factory func(t reflect.Type) (Binding, error)
+}
+
+func (b *genericBind) typ() reflect.Type { return b.sample }
+func (b *genericBind) scope() string     { return b.key }
+func (b *genericBind) eager() bool       { return false }
+func (b *genericBind) kind() Kind        { return KindGeneric }
+
+func (b *genericBind) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	return reflect.Value{}, false, bindingError(ErrNoSuchBinding, b.sample, b.key)
+}
+
+func (b *genericBind) For(k string) Binding {
+	b.key = k
+	return b
+}
+
+func (b *genericBind) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
+
+func (b *genericBind) configure(bs *bindings) (err error) {
+	for _, g := range bs.generics[b.family] {
+		if g.key == b.key {
+			return bindingError(ErrDuplicate, b.sample, b.key)
+		}
+	}
+
+	if bs.generics == nil {
+		bs.generics = make(map[string][]*genericBind)
+	}
+
+	bs.generics[b.family] = append(bs.generics[b.family], b)
+
+	return
+}
+
+// instantiate the binding of type t for key k with a generic binding
+// of bs only.
+//
+// The binding is created once and reused for later lookups. The result
+// is false if there is no generic binding for t or if its factory
+// didn't return a binding.
+func (bs *bindings) instantiate(family string, t reflect.Type, k string) (Binding, bool) {
+	key := lookupKey{t: t, k: k}
+
+	bs.mut.RLock()
+	res, done := bs.instances[key]
+	var generic *genericBind
+
+	for _, g := range bs.generics[family] {
+		if g.key == k {
+			generic = g
+		}
+	}
+
+	bs.mut.RUnlock()
+
+	if done || generic == nil {
+		return res, res != nil
+	}
+
+	bs.mut.Lock()
+	once, ok := bs.pending[key]
+
+	if !ok {
+		if bs.pending == nil {
+			bs.pending = make(map[lookupKey]*sync.Once)
+		}
+
+		once = &sync.Once{}
+		bs.pending[key] = once
+	}
+
+	bs.mut.Unlock()
+
+	// the factory is called without the lock since it may use bs,
+	// concurrent lookups wait for it
+	once.Do(func() {
+		res := generic.instance(t, k)
+
+		bs.mut.Lock()
+		defer bs.mut.Unlock()
+
+		if bs.instances == nil {
+			bs.instances = make(map[lookupKey]Binding)
+		}
+
+		bs.instances[key] = res
+	})
+
+	bs.mut.RLock()
+	defer bs.mut.RUnlock()
+
+	res = bs.instances[key]
+
+	return res, res != nil
+}
+
+// instance of the generic binding for type t and key k created by its
+// factory, nil if the factory didn't return a binding.
+func (b *genericBind) instance(t reflect.Type, k string) (res Binding) {
+	res, err := b.factory(t)
+
+	switch {
+	case err != nil:
+		res = &failedBind{t: t, key: k, err: fmt.Errorf("generic binding for %s: %w", t, err)}
+	case res == nil:
+	case res.typ() != t:
+		res = &failedBind{t: t, key: k, err: fmt.Errorf("generic binding for %s returned a binding for %s", t, res.typ())}
+	case k != "":
+		res = res.For(k)
+	}
+
+	return
+}
+
+// instantiated is true if b has been instantiated by a generic binding of bs.
+func (bs *bindings) instantiated(b Binding) bool {
+	bs.mut.RLock()
+	defer bs.mut.RUnlock()
+
+	return bs.instances[lookupKey{t: b.typ(), k: b.scope()}] == b
+}
+
+// generic returns the generic binding of bs or its parents that covers
+// type t for key k.
+func (bs *bindings) generic(t reflect.Type, k string) (*genericBind, bool) {
+	family, ok := familyOf(t)
+
+	if !ok {
+		return nil, false
+	}
+
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+		b, found := bb.instances[lookupKey{t: t, k: k}]
+		generics := bb.generics[family]
+		bb.mut.RUnlock()
+
+		if !found || b == nil {
+			continue
+		}
+
+		for _, g := range generics {
+			if g.key == k {
+				return g, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// shadowsGeneric is true if a generic binding of bs or its parents
+// for the same family and key takes precedence over g.
+func (bs *bindings) shadowsGeneric(g *genericBind) bool {
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+		generics := bb.generics[g.family]
+		bb.mut.RUnlock()
+
+		for _, other := range generics {
+			if other.key == g.key {
+				return other != g
+			}
+		}
+	}
+
+	return false
+}
+
+// failedBind represents an instantiation of a generic binding that
+// failed. Resolving it returns the error.
+type failedBind struct {
+	t   reflect.Type
+	key string
+	err error
+}
+
+func (b *failedBind) typ() reflect.Type { return b.t }
+func (b *failedBind) scope() string     { return b.key }
+func (b *failedBind) eager() bool       { return false }
+func (b *failedBind) kind() Kind        { return KindGeneric }
+
+func (b *failedBind) deps() ([]dependency, error) { return nil, b.err }
+
+func (b *failedBind) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	return reflect.Value{}, false, b.err
+}
+
+func (b *failedBind) For(k string) Binding {
+	b.key = k
+	return b
+}
+
+func (b *failedBind) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
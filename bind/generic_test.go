@@ -0,0 +1,253 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joa/goety/bind"
+)
+
+type genericCache[K comparable, V any] interface {
+	Put(k K, v V)
+	Get(k K) V
+}
+
+type lruCache[K comparable, V any] struct {
+	values map[K]V
+}
+
+func (c *lruCache[K, V]) Put(k K, v V) {
+	if c.values == nil {
+		c.values = make(map[K]V)
+	}
+
+	c.values[k] = v
+}
+
+func (c *lruCache[K, V]) Get(k K) V { return c.values[k] }
+
+type genericUser struct {
+	Users  genericCache[string, int] `bind:"-"`
+	Orders genericCache[int, string] `bind:"-"`
+}
+
+func typeOf[T any]() reflect.Type {
+	var zero *T
+	return reflect.TypeOf(zero).Elem()
+}
+
+func TestGeneric(t *testing.T) {
+	calls := 0
+
+	// implementations of generic interfaces can't be derived at runtime
+	ctx, err := bind.Configure(context.Background(),
+		bind.Generic[genericCache[any, any]](func(t reflect.Type) (bind.Binding, error) {
+			calls++
+
+			switch t {
+			case typeOf[genericCache[string, int]]():
+				return bind.ImplementationOnce[genericCache[string, int], *lruCache[string, int]](), nil
+			case typeOf[genericCache[int, string]]():
+				return bind.ImplementationOnce[genericCache[int, string], *lruCache[int, string]](), nil
+			}
+
+			return nil, nil
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	u := bind.New[*genericUser](ctx)
+	u.Users.Put("a", 1)
+	u.Orders.Put(1, "a")
+
+	if _, ok := u.Users.(*lruCache[string, int]); !ok {
+		t.Errorf("expected *lruCache[string, int], got %T", u.Users)
+	}
+
+	if v := bind.Get[genericCache[string, int]](ctx).Get("a"); v != 1 {
+		t.Errorf("expected the same instance, got %d", v)
+	}
+
+	if calls != 2 {
+		t.Errorf("expected the factory to be called once per instantiation, got %d", calls)
+	}
+
+	if _, err = bind.TryGet[genericCache[string, string]](ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %v", err)
+	}
+
+	if d, _ := bind.Describe(ctx); !strings.Contains(d.String(), "(generic)") {
+		t.Errorf("expected the generic binding to be described, got\n%s", d)
+	}
+}
+
+func TestGeneric_Exact(t *testing.T) {
+	exact := &lruCache[string, int]{}
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Generic[genericCache[any, any]](func(t reflect.Type) (bind.Binding, error) {
+			return nil, errors.New("boom")
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.Instance[genericCache[string, int]](exact))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if v := bind.Get[genericCache[string, int]](child); v != exact {
+		t.Errorf("expected the exact binding, got %v", v)
+	}
+
+	if _, err = bind.TryGet[genericCache[string, int]](ctx); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected the error of the factory, got %v", err)
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error without dependencies, got %s", err)
+	}
+}
+
+type genericRepository[T any] struct {
+	Table string `bind:"table"`
+}
+
+type genericService struct {
+	Users  *genericRepository[string] `bind:"-"`
+	Orders *genericRepository[int]    `bind:"-"`
+}
+
+func TestGenericType(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("items").For("table"),
+		bind.GenericType[*genericRepository[any]]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	svc := bind.New[*genericService](ctx)
+
+	if svc.Users == nil || svc.Orders == nil || svc.Users.Table != "items" || svc.Orders.Table != "items" {
+		t.Errorf("expected both instantiations to be injected, got %+v", svc)
+	}
+
+	if bind.Get[*genericRepository[string]](ctx) == svc.Users {
+		t.Error("expected a new instance per resolution")
+	}
+
+	if _, err = bind.TryGet[genericRepository[string]](ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding for the non-pointer type, got %v", err)
+	}
+}
+
+func TestGenericOnce(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("items").For("table"),
+		bind.GenericOnce[*genericRepository[any]]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.String("other").For("table"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	svc := bind.New[*genericService](child)
+
+	if svc.Users != bind.Get[*genericRepository[string]](ctx) || svc.Orders != bind.Get[*genericRepository[int]](ctx) {
+		t.Errorf("expected one instance per instantiation, got %+v", svc)
+	}
+
+	if svc.Users.Table != "items" {
+		t.Errorf("expected the instance to be solved where the binding is configured, got %s", svc.Users.Table)
+	}
+}
+
+func TestGenericType_Interface(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+
+	bind.GenericType[genericCache[any, any]]()
+}
+
+func TestGeneric_Concurrent(t *testing.T) {
+	var calls atomic.Int32
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Generic[*genericRepository[any]](func(t reflect.Type) (bind.Binding, error) {
+			calls.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return bind.Type[*genericRepository[string]](), nil
+		}),
+		bind.String("items").For("table"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := bind.TryGet[*genericRepository[string]](ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected the factory to be called once, got %d", n)
+	}
+}
+
+func TestGeneric_NotGeneric(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+
+	bind.Generic[string](func(reflect.Type) (bind.Binding, error) { return nil, nil })
+}
@@ -0,0 +1,251 @@
+package bind
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Event describes a step of a resolution for hooks.
+type Event struct {
+	Type     reflect.Type  // type that has been requested, instantiated or initialized
+	Key      string        // key of the requested type
+	Field    string        // struct field that requested the type, if any
+	Binding  Binding       // binding that is bound for Type, if any
+	Concrete Binding       // binding that has been solved, if a more concrete binding has been chosen it differs from Binding
+	Layer    int           // layer of Binding, 0 for the context in which Type is resolved, 1 for its parent, ...
+	Duration time.Duration // duration of the step
+	Err      error         // error of the step, if any
+}
+
+// Hook is notified about the steps of resolutions.
+//
+// All methods must be safe for concurrent use.
+type Hook interface {
+	// OnResolve is called when a requested type has been resolved.
+	OnResolve(ctx context.Context, e Event)
+
+	// OnInstantiate is called when a new instance has been created.
+	OnInstantiate(ctx context.Context, e Event)
+
+	// OnInitialize is called when an instance has been initialized.
+	OnInitialize(ctx context.Context, e Event)
+
+	// OnError is called when a resolution started by the public API fails.
+	OnError(ctx context.Context, e Event)
+}
+
+// Hooks - Notify h about all resolutions.
+//
+// This is an option of Configure. The hook h is notified about all
+// resolutions in the configured context or its children, including
+// nested dependencies. It receives the context in which the resolution
+// has been started. Hooks of parent contexts are notified first.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.Hooks(bind.LogHook(slog.Default())))
+func Hooks(h Hook) Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.hooks.resolve = append(bs.hooks.resolve, h.OnResolve)
+			bs.hooks.instantiate = append(bs.hooks.instantiate, h.OnInstantiate)
+			bs.hooks.initialize = append(bs.hooks.initialize, h.OnInitialize)
+			bs.hooks.error = append(bs.hooks.error, h.OnError)
+			return nil
+		},
+	}
+}
+
+// OnResolve - Call f whenever a requested type has been resolved.
+//
+// This includes nested dependencies and failed resolutions. The event
+// names the binding that has been chosen and the layer it came from.
+// See Hooks for more information.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.OnResolve(func(ctx context.Context, e bind.Event) {
+//      log.Printf("resolved %s for %q in %s: %v", e.Type, e.Key, e.Duration, e.Err)
+//    }))
+func OnResolve(f func(ctx context.Context, e Event)) Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.hooks.resolve = append(bs.hooks.resolve, f)
+			return nil
+		},
+	}
+}
+
+// OnInstantiate - Call f whenever a new instance has been created.
+//
+// Instances are created by allocation, providers and constructors.
+// The instance isn't initialized yet. See Hooks for more information.
+func OnInstantiate(f func(ctx context.Context, e Event)) Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.hooks.instantiate = append(bs.hooks.instantiate, f)
+			return nil
+		},
+	}
+}
+
+// OnInitialize - Call f whenever an instance has been initialized.
+//
+// An instance is initialized when its fields have been injected and
+// InitAfter has been called. See Hooks for more information.
+func OnInitialize(f func(ctx context.Context, e Event)) Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.hooks.initialize = append(bs.hooks.initialize, f)
+			return nil
+		},
+	}
+}
+
+// OnError - Call f whenever a resolution fails.
+//
+// Unlike OnResolve, f is only called once per failed call of the public
+// API, e.g. bind.TryGet, or per eager binding that fails in Configure.
+// See Hooks for more information.
+func OnError(f func(ctx context.Context, e Event)) Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.hooks.error = append(bs.hooks.error, f)
+			return nil
+		},
+	}
+}
+
+// Logger logs structured messages with key-value pairs.
+//
+// *slog.Logger implements Logger.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+}
+
+// LogHook returns a hook that logs all events to l.
+//
+// Errors are logged with ErrorContext, all other events with DebugContext.
+func LogHook(l Logger) Hook {
+	return &logHook{l: l}
+}
+
+// logHook logs events to a Logger.
+type logHook struct {
+	l Logger
+}
+
+func (h *logHook) OnResolve(ctx context.Context, e Event) {
+	h.l.DebugContext(ctx, "bind: resolved", e.args()...)
+}
+
+func (h *logHook) OnInstantiate(ctx context.Context, e Event) {
+	h.l.DebugContext(ctx, "bind: instantiated", e.args()...)
+}
+
+func (h *logHook) OnInitialize(ctx context.Context, e Event) {
+	h.l.DebugContext(ctx, "bind: initialized", e.args()...)
+}
+
+func (h *logHook) OnError(ctx context.Context, e Event) {
+	h.l.ErrorContext(ctx, "bind: resolution failed", e.args()...)
+}
+
+// args of the event as key-value pairs for a Logger.
+func (e Event) args() (res []any) {
+	res = append(res, "type", e.Type.String())
+
+	if e.Key != "" {
+		res = append(res, "key", e.Key)
+	}
+
+	if e.Field != "" {
+		res = append(res, "field", e.Field)
+	}
+
+	if e.Concrete != nil {
+		res = append(res, "binding", e.Concrete.typ().String(), "layer", e.Layer)
+	}
+
+	if k, ok := e.Concrete.(bindingKind); ok {
+		res = append(res, "kind", string(k.kind()))
+	}
+
+	res = append(res, "duration", e.Duration)
+
+	if e.Err != nil {
+		res = append(res, "error", e.Err)
+	}
+
+	return
+}
+
+// hookFunc is called with an event of a resolution.
+type hookFunc func(ctx context.Context, e Event)
+
+// hooks of a context.
+type hooks struct {
+	resolve     []hookFunc
+	instantiate []hookFunc
+	initialize  []hookFunc
+	error       []hookFunc
+}
+
+// emit the event e to the hooks of r that are selected by kind.
+func (r *resolution) emit(kind func(h *hooks) []hookFunc, e Event) {
+	if r.hooks == nil {
+		return
+	}
+
+	for _, f := range kind(r.hooks) {
+		f(r.ctx, e)
+	}
+}
+
+// now returns the current time if r has hooks, otherwise the zero time.
+//
+// Durations are only measured for hooks since it isn't free.
+func (r *resolution) now() time.Time {
+	if r.hooks == nil {
+		return time.Time{}
+	}
+
+	return time.Now()
+}
+
+// since returns the duration since start, 0 if start is the zero time.
+func since(start time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+
+	return time.Since(start)
+}
+
+// empty is true if there are no hooks at all.
+func (h *hooks) empty() bool {
+	return len(h.resolve)+len(h.instantiate)+len(h.initialize)+len(h.error) == 0
+}
+
+func resolveHooks(h *hooks) []hookFunc     { return h.resolve }
+func instantiateHooks(h *hooks) []hookFunc { return h.instantiate }
+func initializeHooks(h *hooks) []hookFunc  { return h.initialize }
+func errorHooks(h *hooks) []hookFunc       { return h.error }
+
+// instantiated emits an OnInstantiate event for the value v of r that has
+// been created since start.
+func (r *resolution) instantiated(v reflect.Value, start time.Time) {
+	r.emit(instantiateHooks, Event{Type: v.Type(), Concrete: r.current(), Duration: since(start)})
+}
+
+// failed emits an OnError event if r has been started by the public API
+// and isn't solving any binding anymore.
+func (r *resolution) failed(e Event) {
+	if e.Err != nil && len(r.chain) == 0 {
+		r.emit(errorHooks, e)
+	}
+}
@@ -0,0 +1,207 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type hookService struct {
+	Name string `bind:"name"`
+}
+
+func TestOnResolve(t *testing.T) {
+	var (
+		mut    sync.Mutex
+		events []bind.Event
+	)
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.OnResolve(func(_ context.Context, e bind.Event) {
+			mut.Lock()
+			defer mut.Unlock()
+			events = append(events, e)
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	ctx, err = bind.Configure(ctx,
+		bind.Instance[string]("svc").For("name"),
+		bind.Type[*hookService](),
+		bind.Provider[int](func() (int, error) {
+			return 0, errors.New("failed")
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	bind.Get[*hookService](ctx)
+
+	if _, err = bind.TryGet[int](ctx); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+
+	// nested dependencies are reported first
+	if e := events[0]; e.Type.String() != "string" || e.Key != "name" || e.Field != "hookService.Name" {
+		t.Errorf("unexpected event %+v", e)
+	}
+
+	if e := events[1]; e.Type.String() != "*bind_test.hookService" || e.Err != nil {
+		t.Errorf("unexpected event %+v", e)
+	}
+
+	if e := events[2]; e.Type.String() != "int" || e.Err == nil {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+type hookRecorder struct {
+	mut    sync.Mutex
+	events map[string][]bind.Event
+}
+
+func (h *hookRecorder) record(kind string, e bind.Event) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	if h.events == nil {
+		h.events = make(map[string][]bind.Event)
+	}
+
+	h.events[kind] = append(h.events[kind], e)
+}
+
+func (h *hookRecorder) OnResolve(_ context.Context, e bind.Event)     { h.record("resolve", e) }
+func (h *hookRecorder) OnInstantiate(_ context.Context, e bind.Event) { h.record("instantiate", e) }
+func (h *hookRecorder) OnInitialize(_ context.Context, e bind.Event)  { h.record("initialize", e) }
+func (h *hookRecorder) OnError(_ context.Context, e bind.Event)       { h.record("error", e) }
+
+type hookIface interface{}
+
+type hookUnbound interface {
+	Unbound()
+}
+
+type hookDependent struct {
+	Missing int `bind:"missing"`
+}
+
+func TestHooks(t *testing.T) {
+	h := &hookRecorder{}
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Hooks(h),
+		bind.Implementation[hookIface, *hookService](),
+		bind.Type[*hookDependent]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	ctx, err = bind.Configure(ctx,
+		bind.Instance[string]("svc").For("name"),
+		bind.Type[*hookService]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	bind.Get[hookIface](ctx)
+
+	resolved := h.events["resolve"]
+
+	if len(resolved) != 2 {
+		t.Fatalf("expected 2 resolutions, got %d", len(resolved))
+	}
+
+	if e := resolved[1]; e.Layer != 1 || e.Binding == e.Concrete || e.Type.String() != "bind_test.hookIface" {
+		t.Errorf("expected a more concrete binding to be chosen, got %+v", e)
+	}
+
+	if n := len(h.events["instantiate"]); n != 1 {
+		t.Errorf("expected 1 instantiation, got %d", n)
+	}
+
+	if e := h.events["initialize"]; len(e) != 1 || e[0].Type.String() != "*bind_test.hookService" {
+		t.Errorf("expected an initialization of *hookService, got %+v", e)
+	}
+
+	if n := len(h.events["error"]); n != 0 {
+		t.Errorf("expected no errors, got %d", n)
+	}
+
+	if _, err = bind.TryGet[*hookDependent](ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Fatalf("expected ErrNoSuchBinding, got %v", err)
+	}
+
+	if e := h.events["error"]; len(e) != 1 || e[0].Type.String() != "*bind_test.hookDependent" {
+		t.Errorf("expected exactly one error for *hookDependent, got %+v", e)
+	}
+
+	if _, err = bind.TryNew[hookUnbound](ctx); !errors.Is(err, bind.ErrUnsatisfiedInterface) {
+		t.Fatalf("expected ErrUnsatisfiedInterface, got %v", err)
+	}
+
+	if e := h.events["error"]; len(e) != 2 || e[1].Type.String() != "bind_test.hookUnbound" || e[1].Err != err {
+		t.Errorf("expected an error for hookUnbound, got %+v", e)
+	}
+}
+
+type hookLogger struct {
+	mut      sync.Mutex
+	messages []string
+	args     [][]any
+}
+
+func (l *hookLogger) DebugContext(_ context.Context, msg string, args ...any) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.messages = append(l.messages, msg)
+	l.args = append(l.args, args)
+}
+
+func (l *hookLogger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.DebugContext(ctx, "error: "+msg, args...)
+}
+
+func TestLogHook(t *testing.T) {
+	l := &hookLogger{}
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Hooks(bind.LogHook(l)),
+		bind.Instance[string]("svc").For("name"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	bind.For[string](ctx, "name")
+
+	if _, err = bind.TryGet[int](ctx); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(l.messages) != 2 || l.messages[0] != "bind: resolved" || l.messages[1] != "error: bind: resolution failed" {
+		t.Fatalf("unexpected messages %v", l.messages)
+	}
+
+	if args := l.args[0]; len(args) < 4 || args[0] != "type" || args[1] != "string" || args[2] != "key" || args[3] != "name" {
+		t.Errorf("unexpected args %v", args)
+	}
+}
@@ -0,0 +1,171 @@
+package bind
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lazy is a handle that resolves an instance of T when it's used first.
+//
+// Fields of type Lazy are injected with a handle bound to the context in
+// which the owner of the field is resolved. T is resolved with the first
+// call of Get, further calls return the same instance. A bind struct tag
+// is optional but can be used for the key and the options of T.
+//
+// Since T isn't resolved while the owner is initialized, Lazy can be used
+// to break dependency cycles. Calling Get while the owner is still being
+// initialized, e.g. in InitAfter, fails with ErrCycle if T is the owner
+// or depends on it again.
+//
+// Example
+//
+//  type Service struct {
+//    DB bind.Lazy[Database] `bind:"primary"`
+//  }
+//
+//  svc := bind.New[*Service](ctx) // Database isn't resolved yet
+//  db := svc.DB.Get()             // resolves Database
+type Lazy[T any] struct {
+	h *lazyHandle[T]
+}
+
+type lazyHandle[T any] struct {
+	once    sync.Once
+	resolve resolver
+	value   T
+	err     error
+}
+
+// Get returns the instance of T and resolves it if necessary.
+//
+// This method panics if T can't be resolved. Use TryGet to work with
+// the error instead.
+func (l Lazy[T]) Get() T {
+	res, err := l.TryGet()
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// TryGet returns the instance of T or an error.
+//
+// A failed resolution isn't retried, the error is returned again.
+func (l Lazy[T]) TryGet() (res T, err error) {
+	if l.h == nil {
+		return resolveHandle[T](nil)
+	}
+
+	l.h.once.Do(func() {
+		l.h.value, l.h.err = resolveHandle[T](l.h.resolve)
+	})
+
+	return l.h.value, l.h.err
+}
+
+func (l *Lazy[T]) target() reflect.Type { return typeOf[T]() }
+
+func (l *Lazy[T]) bind(resolve resolver) {
+	l.h = &lazyHandle[T]{resolve: resolve}
+}
+
+// Factory is a handle that resolves a new instance of T with every use.
+//
+// Fields of type Factory are injected like Lazy. Unlike Lazy, T is
+// resolved with every call of Get. Whether this results in a new instance
+// depends on the binding of T, e.g. Once bindings always return the same
+// instance.
+//
+// Example
+//
+//  type Worker struct {
+//    NewJob bind.Factory[*Job]
+//  }
+//
+//  job := worker.NewJob.Get() // a new *Job for every call
+type Factory[T any] struct {
+	resolve resolver
+}
+
+// Get resolves and returns an instance of T.
+//
+// This method panics if T can't be resolved. Use TryGet to work with
+// the error instead.
+func (f Factory[T]) Get() T {
+	res, err := f.TryGet()
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// TryGet resolves and returns an instance of T or an error.
+func (f Factory[T]) TryGet() (res T, err error) {
+	return resolveHandle[T](f.resolve)
+}
+
+func (f *Factory[T]) target() reflect.Type { return typeOf[T]() }
+
+func (f *Factory[T]) bind(resolve resolver) {
+	f.resolve = resolve
+}
+
+// resolver resolves the dependency of a handle.
+//
+// The result is false if the dependency is optional and not bound.
+type resolver func() (res reflect.Value, found bool, err error)
+
+// handleValue is implemented by pointers to Lazy and Factory.
+type handleValue interface {
+	// target is the type that is resolved by the handle.
+	target() reflect.Type
+
+	// bind the handle to resolve.
+	bind(resolve resolver)
+}
+
+var handleValueType = typeOf[handleValue]()
+
+// handleDependency returns the dependency of a field of type t.
+//
+// If t is a Lazy or Factory type the dependency is on its target type.
+func handleDependency(t reflect.Type) (d dependency) {
+	d.typ = t
+
+	if reflect.PointerTo(t).Implements(handleValueType) {
+		d.typ = reflect.New(t).Interface().(handleValue).target()
+		d.handle = t
+	}
+
+	return
+}
+
+// resolveHandle resolves an instance of T with resolve.
+func resolveHandle[T any](resolve resolver) (res T, err error) {
+	t := typeOf[T]()
+
+	if resolve == nil {
+		err = fmt.Errorf("%w: handle of %s hasn't been injected", ErrContextWithoutBindings, t)
+		return
+	}
+
+	v, found, err := resolve()
+
+	if found && err == nil {
+		res = unboxValue[T](t, v)
+	}
+
+	return
+}
+
+// handle of the dependency d that resolves d in ctx when it's used.
+func (bs *bindings) handle(ctx context.Context, d dependency) reflect.Value {
+	res := reflect.New(d.handle)
+	res.Interface().(handleValue).bind(func() (reflect.Value, bool, error) {
+		return bs.resolveField(newResolution(ctx), d)
+	})
+
+	return res.Elem()
+}
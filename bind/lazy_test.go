@@ -0,0 +1,159 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joa/goety/bind"
+)
+
+type lazyJob struct {
+	ID int
+}
+
+type lazyWorker struct {
+	Name   bind.Lazy[string] `bind:"name"`
+	Job    bind.Lazy[*lazyJob]
+	NewJob bind.Factory[*lazyJob]
+}
+
+func TestLazy(t *testing.T) {
+	resolved := 0
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Provider[string](func() (string, error) {
+			resolved++
+			return "worker", nil
+		}).For("name"),
+		bind.Type[*lazyJob]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	w := bind.New[*lazyWorker](ctx)
+
+	if resolved != 0 {
+		t.Errorf("expected no resolution before Get, got %d", resolved)
+	}
+
+	if w.Name.Get() != "worker" || w.Name.Get() != "worker" || resolved != 1 {
+		t.Errorf("expected exactly one resolution, got %d", resolved)
+	}
+
+	if w.Job.Get() != w.Job.Get() {
+		t.Error("expected the same instance of Lazy")
+	}
+
+	if w.NewJob.Get() == w.NewJob.Get() {
+		t.Error("expected new instances of Factory")
+	}
+}
+
+func TestLazy_NotInjected(t *testing.T) {
+	var l bind.Lazy[string]
+
+	if _, err := l.TryGet(); !errors.Is(err, bind.ErrContextWithoutBindings) {
+		t.Errorf("expected ErrContextWithoutBindings, got %v", err)
+	}
+
+	var f bind.Factory[string]
+
+	if _, err := f.TryGet(); !errors.Is(err, bind.ErrContextWithoutBindings) {
+		t.Errorf("expected ErrContextWithoutBindings, got %v", err)
+	}
+}
+
+func TestLazy_Missing(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Type[*lazyJob](),
+		bind.Type[*lazyWorker]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %v", err)
+	}
+
+	w := bind.New[*lazyWorker](ctx)
+
+	if _, err = w.Name.TryGet(); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %v", err)
+	}
+}
+
+type lazyParent struct {
+	Child bind.Lazy[*lazyChild]
+}
+
+type lazyChild struct {
+	Parent *lazyParent `bind:"-"`
+}
+
+func TestLazy_Cycle(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Once[*lazyParent](),
+		bind.Once[*lazyChild]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	parent := bind.Get[*lazyParent](ctx)
+
+	if parent.Child.Get().Parent != parent {
+		t.Error("expected the child to reference its parent")
+	}
+}
+
+type factoryOwner struct {
+	New bind.Factory[*factoryOwner]
+}
+
+func (o *factoryOwner) InitAfter() error {
+	_, err := o.New.TryGet()
+	return err
+}
+
+func TestFactory_Cycle(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Type[*factoryOwner]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := bind.TryGet[*factoryOwner](ctx)
+		done <- err
+	}()
+
+	select {
+	case err = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected ErrCycle, got an endless recursion")
+		return
+	}
+
+	if !errors.Is(err, bind.ErrCycle) {
+		t.Errorf("expected ErrCycle, got %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package bind
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// instance is created once and owned by bindings.
+type instance struct {
+	mut   sync.Mutex
+	done  bool
+	inst  reflect.Value
+	owner *resolution   // resolution that initializes the instance, if any, see waits
+	ready chan struct{} // closed when owner finished the initialization
+
+	decorated map[decoratedKey]reflect.Value // decorated values of inst
+}
+
+// waits holds the resolutions that wait for instances which are
+// initialized by other resolutions.
+//
+// The owners of instances are only changed while holding both the lock
+// of the instance and this lock, hence waits can be walked without
+// acquiring the locks of the instances.
+var waits = struct {
+	sync.Mutex
+	on map[*resolution]*instance
+}{on: make(map[*resolution]*instance)}
+
+// get the instance or create one of type t that's initialized and owned by bs.
+//
+// Concurrent callers wait for the initialization to complete. The lock
+// isn't held while the instance is initialized since this runs user code,
+// e.g. InitAfter, which may start new resolutions with handles or the
+// context of a provider. If such a resolution requests the instance it
+// would wait for itself, hence it fails with ErrCycle instead. The same
+// applies if the owner waits for r directly or indirectly, e.g. when two
+// goroutines initialize instances that depend on each other.
+func (i *instance) get(bs *bindings, r *resolution, t reflect.Type) (value reflect.Value, err error) {
+	i.mut.Lock()
+
+	for !i.done && i.owner != nil {
+		if !r.wait(i) {
+			i.mut.Unlock()
+			err = r.cycle(t)
+			return
+		}
+
+		ready := i.ready
+		i.mut.Unlock()
+		<-ready
+		r.waited()
+		i.mut.Lock()
+	}
+
+	if i.done {
+		value = i.inst
+		i.mut.Unlock()
+		return
+	}
+
+	i.ready = make(chan struct{})
+	i.own(r)
+	i.mut.Unlock()
+
+	value, err = i.create(bs, r, t)
+
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	if err == nil {
+		i.inst, i.done = value, true
+	}
+
+	i.own(nil)
+	close(i.ready)
+
+	return
+}
+
+// own the instance by the resolution r, nil if the initialization finished.
+//
+// The lock of the instance must be held.
+func (i *instance) own(r *resolution) {
+	waits.Lock()
+	defer waits.Unlock()
+
+	i.owner = r
+}
+
+// wait registers that r waits for the instance i.
+//
+// The result is false if waiting would never end since the owner of i,
+// one of the resolutions it started or one they wait for in turn waits
+// for r or one of its ancestors.
+func (r *resolution) wait(i *instance) bool {
+	waits.Lock()
+	defer waits.Unlock()
+
+	visited := make(map[*instance]bool)
+
+	for next := []*instance{i}; len(next) > 0; {
+		j := next[len(next)-1]
+		next = next[:len(next)-1]
+
+		if visited[j] || j.owner == nil {
+			continue
+		}
+
+		visited[j] = true
+
+		if r.descends(j.owner) {
+			return false
+		}
+
+		for w, k := range waits.on {
+			if w.descends(j.owner) {
+				next = append(next, k)
+			}
+		}
+	}
+
+	waits.on[r] = i
+
+	return true
+}
+
+// waited removes the registration of wait.
+func (r *resolution) waited() {
+	waits.Lock()
+	defer waits.Unlock()
+
+	delete(waits.on, r)
+}
+
+// decoratedValue of the instance for key, if any.
+func (i *instance) decoratedValue(key decoratedKey) (v reflect.Value, ok bool) {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	v, ok = i.decorated[key]
+
+	return
+}
+
+// storeDecorated stores the decorated value v of the instance for key
+// unless there is one already. The stored value is returned.
+func (i *instance) storeDecorated(key decoratedKey, v reflect.Value) reflect.Value {
+	i.mut.Lock()
+	defer i.mut.Unlock()
+
+	if existing, ok := i.decorated[key]; ok {
+		return existing
+	}
+
+	if i.decorated == nil {
+		i.decorated = make(map[decoratedKey]reflect.Value)
+	}
+
+	i.decorated[key] = v
+
+	return v
+}
+
+// create an instance of type t that's initialized and owned by bs.
+func (i *instance) create(bs *bindings, r *resolution, t reflect.Type) (value reflect.Value, err error) {
+	start := r.now()
+
+	if value, err = alloc(t); err != nil {
+		return
+	}
+
+	r.instantiated(value, start)
+
+	if err = bs.initialize(r, value.Type(), value); err != nil {
+		return
+	}
+
+	bs.own(value)
+
+	return
+}
+
+// own the instance v so that it is disposed on shutdown.
+//
+// Instances are owned after they have been initialized. Since the
+// dependencies of an instance are initialized first, the order of
+// owned instances is also a valid dependency order.
+func (bs *bindings) own(v reflect.Value) {
+	bs.mut.Lock()
+	defer bs.mut.Unlock()
+
+	bs.owned = append(bs.owned, v)
+}
+
+// shutdown disposes all owned instances in reverse order of their creation.
+//
+// All errors are collected and returned at once. Calling shutdown
+// more than once is safe.
+func (bs *bindings) shutdown() error {
+	bs.mut.Lock()
+	owned := bs.owned
+	bs.owned = nil
+	bs.mut.Unlock()
+
+	var errs []error
+
+	for i := len(owned) - 1; i >= 0; i-- {
+		if err := dispose(owned[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// dispose v if it is a Disposer or an io.Closer.
+func dispose(v reflect.Value) (err error) {
+	switch d := v.Interface().(type) {
+	case Disposer:
+		err = d.Dispose()
+	case io.Closer:
+		err = d.Close()
+	}
+
+	return
+}
+
+// owner returns the bindings in which b has been configured or
+// instantiated by a generic binding.
+//
+// If b isn't configured in bs or any of its parents bs is returned.
+func (bs *bindings) owner(b Binding) *bindings {
+	for bb := bs; bb != nil; bb = bb.parent {
+		if found, _ := bb.lookup(b.typ(), b.scope()); found == b || bb.instantiated(b) {
+			return bb
+		}
+	}
+
+	return bs
+}
@@ -0,0 +1,337 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joa/goety/bind"
+)
+
+var disposed []string
+
+type lifecycleDB struct{}
+
+func (db *lifecycleDB) Close() error {
+	disposed = append(disposed, "db")
+	return nil
+}
+
+type lifecycleRepo struct {
+	DB *lifecycleDB `bind:"-"`
+}
+
+func (r *lifecycleRepo) Dispose() error {
+	disposed = append(disposed, "repo")
+	return errors.New("repo")
+}
+
+func TestShutdown(t *testing.T) {
+	disposed = nil
+
+	// configure the repository first to ensure order
+	// doesn't depend on the configuration
+	ctx, err := bind.Configure(context.Background(),
+		bind.Once[*lifecycleRepo](),
+		bind.Once[*lifecycleDB]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	child, err := bind.Configure(ctx, bind.String("child"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Shutdown(child); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	if len(disposed) != 0 {
+		t.Errorf("expected no disposed instances, got %v", disposed)
+	}
+
+	if err = bind.Shutdown(ctx); err == nil || err.Error() != "repo" {
+		t.Errorf("expected repo, got %s", err)
+	}
+
+	if len(disposed) != 2 || disposed[0] != "repo" || disposed[1] != "db" {
+		t.Errorf("expected [repo db], got %v", disposed)
+	}
+
+	if err = bind.Shutdown(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	if len(disposed) != 2 {
+		t.Errorf("expected no further disposals, got %v", disposed)
+	}
+}
+
+type lifecycleSession struct {
+	closed bool
+}
+
+func (s *lifecycleSession) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestShutdown_ReusedModule(t *testing.T) {
+	module := bind.NewModule("session",
+		bind.Once[*lifecycleSession]())
+
+	ctx, err := bind.Configure(context.Background(), module)
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	first := bind.Get[*lifecycleSession](ctx)
+
+	if err = bind.Shutdown(ctx); err != nil || !first.closed {
+		t.Fatalf("expected the first session to be closed, got %v", err)
+		return
+	}
+
+	if ctx, err = bind.Configure(context.Background(), module); err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	second := bind.Get[*lifecycleSession](ctx)
+
+	if second == first || second.closed {
+		t.Error("expected a new session for the second context")
+	}
+
+	if err = bind.Shutdown(ctx); err != nil || !second.closed {
+		t.Errorf("expected the second context to own its session, got %v", err)
+	}
+}
+
+var closed = make(chan struct{})
+
+type lifecycleConn struct{}
+
+func (c *lifecycleConn) Close() error {
+	close(closed)
+	return nil
+}
+
+func TestShutdownCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := bind.Configure(ctx, bind.Once[*lifecycleConn]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	cancel()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Error("expected *lifecycleConn to be closed")
+	}
+}
+
+type handleCycleA struct {
+	B bind.Lazy[*handleCycleB]
+}
+
+func (a *handleCycleA) InitAfter() error {
+	_, err := a.B.TryGet()
+	return err
+}
+
+type handleCycleB struct {
+	A *handleCycleA `bind:"-"`
+}
+
+func TestOnce_HandleCycle(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.OnceLazy[*handleCycleA](),
+		bind.Type[*handleCycleB]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := bind.TryGet[*handleCycleA](ctx)
+		done <- err
+	}()
+
+	select {
+	case err = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected ErrCycle, got a deadlock")
+		return
+	}
+
+	if !errors.Is(err, bind.ErrCycle) {
+		t.Errorf("expected ErrCycle, got %v", err)
+	}
+
+	if _, err = bind.Configure(context.Background(),
+		bind.Once[*handleCycleA](),
+		bind.Type[*handleCycleB]()); !errors.Is(err, bind.ErrCycle) {
+		t.Errorf("expected ErrCycle for an eager Once, got %v", err)
+	}
+}
+
+type waitCycleA struct {
+	Slow string      `bind:"slow"`
+	B    *waitCycleB `bind:"-"`
+}
+
+type waitCycleB struct {
+	Slow string      `bind:"slow"`
+	A    *waitCycleA `bind:"-"`
+}
+
+func TestOnce_WaitCycle(t *testing.T) {
+	testWaitCycle(t,
+		bind.OnceLazy[*waitCycleA](),
+		bind.OnceLazy[*waitCycleB]())
+
+	testWaitCycle(t,
+		bind.Open(bind.Request),
+		bind.Scoped[*waitCycleA](),
+		bind.Scoped[*waitCycleB]())
+}
+
+// testWaitCycle resolves *waitCycleA and *waitCycleB concurrently and
+// expects both resolutions to fail with ErrCycle instead of a deadlock.
+func testWaitCycle(t *testing.T, bindings ...bind.Binding) {
+	t.Helper()
+
+	var (
+		arrived sync.WaitGroup
+		calls   atomic.Int32
+	)
+
+	arrived.Add(2)
+
+	// both goroutines own their instance before they request the other one
+	slow := bind.Provider[string](func() (string, error) {
+		if calls.Add(1) <= 2 {
+			arrived.Done()
+			arrived.Wait()
+		}
+
+		return "slow", nil
+	}).For("slow")
+
+	ctx, err := bind.Configure(context.Background(), append(bindings, slow)...)
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	done := make(chan error, 2)
+
+	go func() {
+		_, err := bind.TryGet[*waitCycleA](ctx)
+		done <- err
+	}()
+
+	go func() {
+		_, err := bind.TryGet[*waitCycleB](ctx)
+		done <- err
+	}()
+
+	for n := 0; n < 2; n++ {
+		select {
+		case err = <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected ErrCycle, got a deadlock")
+			return
+		}
+
+		if !errors.Is(err, bind.ErrCycle) {
+			t.Errorf("expected ErrCycle, got %v", err)
+		}
+	}
+}
+
+type handleProvided struct {
+	Name string
+}
+
+type handleUser struct {
+	P bind.Lazy[*handleProvided]
+	N string
+}
+
+func (u *handleUser) InitAfter() (err error) {
+	p, err := u.P.TryGet()
+
+	if err == nil {
+		u.N = p.Name
+	}
+
+	return
+}
+
+func TestOnce_HandleInitAfter(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Once[*handleUser](),
+		bind.Instance[*handleProvided](&handleProvided{Name: "p"}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if u := bind.Get[*handleUser](ctx); u.N != "p" {
+		t.Errorf("expected the handle to resolve in InitAfter, got %q", u.N)
+	}
+}
+
+func TestOnce_Concurrent(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.OnceLazy[*handleUser](),
+		bind.Instance[*handleProvided](&handleProvided{Name: "p"}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var wg sync.WaitGroup
+
+	res := make([]*handleUser, 8)
+
+	for i := range res {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			res[i] = bind.Get[*handleUser](ctx)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, u := range res {
+		if u != res[0] || u.N != "p" {
+			t.Errorf("expected one initialized instance, got %+v and %+v", u, res[0])
+		}
+	}
+}
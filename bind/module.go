@@ -1,8 +1,11 @@
 package bind
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 )
 
@@ -10,17 +13,275 @@ const (
 	bindTag            = "bind"
 	scopeEmptyDash     = "-"
 	scopeEmptyWildcard = "*"
+	tagSeparator       = ","
+	tagOptional        = "optional"
+	tagDefault         = "default="
 )
 
+// tagOptions of a bind struct tag.
+type tagOptions struct {
+	scope      string
+	optional   bool   // leave the field untouched if there is no binding
+	hasDefault bool   // use def if there is no binding
+	def        string // default value, converted like a configuration value
+}
+
+// parseTag value of a bind struct tag.
+//
+// The tag starts with the scope followed by options separated by ",":
+//
+//  bind:"cache,optional"      // scope "cache", zero value if unbound
+//  bind:"port,default=5432"   // scope "port", 5432 if unbound
+//  bind:"-,default=a,b"       // no scope, []string{"a", "b"} if unbound
+//
+// Since the default value may contain "," itself it must be the last option.
+func parseTag(tag string) (opts tagOptions, err error) {
+	scope, rest, hasOptions := strings.Cut(tag, tagSeparator)
+	opts.scope = normalizeScope(scope)
+
+	for hasOptions {
+		var opt string
+
+		if strings.HasPrefix(rest, tagDefault) {
+			opts.hasDefault = true
+			opts.def = strings.TrimPrefix(rest, tagDefault)
+			break
+		}
+
+		opt, rest, hasOptions = strings.Cut(rest, tagSeparator)
+
+		switch {
+		case opt == tagOptional && !opts.optional:
+			opts.optional = true
+		case opt == tagOptional:
+			return opts, fmt.Errorf(`%w "%s": duplicate option "%s"`, ErrInvalidTag, tag, opt)
+		case opt == "":
+			return opts, fmt.Errorf(`%w "%s": empty option`, ErrInvalidTag, tag)
+		default:
+			return opts, fmt.Errorf(`%w "%s": unknown option "%s"`, ErrInvalidTag, tag, opt)
+		}
+	}
+
+	if opts.optional && opts.hasDefault {
+		err = fmt.Errorf(`%w "%s": options "%s" and "%s" are exclusive`, ErrInvalidTag, tag, tagOptional, tagDefault)
+	}
+
+	return
+}
+
+// fieldDependency of type owner on its struct field f.
+//
+// The result is false if the field has no bind struct tag and is neither
+// Named nor a handle.
+func fieldDependency(owner reflect.Type, f reflect.StructField) (d dependency, inject bool, err error) {
+	tag, tagged := f.Tag.Lookup(bindTag)
+	d, named := qualifiedDependency(f.Type)
+
+	if !named {
+		d = handleDependency(f.Type)
+	}
+
+	if inject = tagged || named || d.handle != nil; !inject {
+		return
+	}
+
+	d.field = owner.Name() + "." + f.Name
+	opts, err := parseTag(tag)
+
+	if err == nil && named && opts.scope != "" {
+		err = fmt.Errorf(`%w "%s": key of a qualified field`, ErrInvalidTag, tag)
+	}
+
+	if err != nil {
+		err = fmt.Errorf("%w (field %s)", err, d.field)
+		return
+	}
+
+	if named {
+		opts.scope = d.scope
+	}
+
+	d.tagOptions = opts
+
+	return
+}
+
+// Module bundles bindings so that they can be installed at once.
+//
+// A module is a Binding itself and installed by passing it to Configure.
+// Modules can include other modules by passing them to NewModule like
+// any other binding. A module that is included multiple times, e.g. by
+// two modules that depend on it, is installed only once per Configure.
+//
+// Modules are parameterized by functions that create them. Errors of
+// Configure, like ErrDuplicate, name the module of the binding.
+//
+// Example
+//
+//  func DatabaseModule(dsn string) *bind.Module {
+//    return bind.NewModule("database",
+//      bind.Instance[string](dsn).For("dsn"),
+//      bind.ImplementationOnce[Database, *sqlDBImpl]())
+//  }
+//
+//  var HTTPModule = bind.NewModule("http",
+//    LoggingModule,
+//    bind.Once[*Server]())
+//
+//  ctx, _ = bind.Configure(ctx,
+//    DatabaseModule("postgres://localhost"),
+//    HTTPModule)
+type Module struct {
+	name     string
+	bindings []Binding
+}
+
+// NewModule creates and returns a new module with a name and bindings.
+func NewModule(name string, bindings ...Binding) *Module {
+	return &Module{name: name, bindings: bindings}
+}
+
+// Name of the module.
+func (m *Module) Name() string { return m.name }
+
+// Bindings of the module in the order in which they have been passed
+// to NewModule, including the modules among them.
+func (m *Module) Bindings() []Binding { return append([]Binding(nil), m.bindings...) }
+
+func (m *Module) typ() reflect.Type { return nil }
+func (m *Module) scope() string     { return "" }
+func (m *Module) eager() bool       { return false }
+
+func (m *Module) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	panic("bind: can't solve a module")
+}
+
+func (m *Module) For(string) Binding { return m }
+func (m *Module) As(any) Binding     { return m }
+
+// Override - Replace or shadow a binding intentionally.
+//
+// Unlike other bindings, b replaces a binding of the same type and key
+// that is configured in the same Configure call instead of failing with
+// ErrDuplicate, regardless of whether that binding comes before or after
+// b. Two overrides of the same type and key still fail. Bindings of parent
+// contexts are shadowed as usual, but this isn't reported in Strict mode.
+// If b is a module, all of its bindings are overrides.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    ProductionModule,
+//    bind.Override(bind.Implementation[Mailer, *fakeMailer]()))
+func Override(b Binding) Binding {
+	return &overrideBind{b: b}
+}
+
+// overrideBind wraps a binding that replaces or shadows another binding.
+//
+// It's unwrapped when the bindings are installed.
+type overrideBind struct {
+	b Binding
+}
+
+func (b *overrideBind) typ() reflect.Type { return b.b.typ() }
+func (b *overrideBind) scope() string     { return b.b.scope() }
+func (b *overrideBind) eager() bool       { return b.b.eager() }
+
+func (b *overrideBind) solve(bs *bindings, r *resolution) (reflect.Value, bool, error) {
+	return b.b.solve(bs, r)
+}
+
+func (b *overrideBind) For(k string) Binding {
+	b.b = b.b.For(k)
+	return b
+}
+
+func (b *overrideBind) As(q any) Binding {
+	b.b = b.b.As(q)
+	return b
+}
+
+// installation of a binding from a module.
+type installation struct {
+	b        Binding
+	module   *Module // nil if the binding isn't part of a module
+	override bool    // the binding has been wrapped with Override
+}
+
+// install bindings and all bindings of the modules among them.
+//
+// Each module is installed only once.
+func install(bindings []Binding) (res []installation) {
+	installed := make(map[*Module]bool)
+
+	var walk func(bindings []Binding, module *Module, override bool)
+
+	walk = func(bindings []Binding, module *Module, override bool) {
+		for _, b := range bindings {
+			o, isOverride := b.(*overrideBind)
+
+			if isOverride {
+				b = o.b
+			}
+
+			m, ok := b.(*Module)
+
+			if !ok {
+				res = append(res, installation{b: b, module: module, override: override || isOverride})
+				continue
+			}
+
+			if !installed[m] {
+				installed[m] = true
+				walk(m.bindings, m, override || isOverride)
+			}
+		}
+	}
+
+	walk(bindings, nil, false)
+
+	return
+}
+
+// moduleError annotates err with the module of the installation i, if any.
+func (i installation) moduleError(err error) error {
+	if err == nil || i.module == nil {
+		return err
+	}
+
+	return fmt.Errorf(`%w (module "%s")`, err, i.module.name)
+}
+
 type typeBindings map[string]Binding
 
 type moduleBindings map[reflect.Type]typeBindings
 
 // bindings is a set of type bindings within a context.
 type bindings struct {
-	mut      sync.RWMutex
-	parent   *bindings
-	bindings moduleBindings
+	mut        sync.RWMutex
+	parent     *bindings
+	bindings   moduleBindings
+	owned      []reflect.Value                // instances to dispose in order of creation
+	onces      scopeCache                     // instances of the Once bindings
+	scopes     map[Scope]*scopeCache          // scopes opened by the bindings
+	multi      map[reflect.Type][]*multiBind  // contributions by type
+	decorators moduleDecorators               // decorators by type and scope
+	sources    []source                       // sources of configuration values
+	modules    map[Binding]*Module            // modules of the bindings installed from modules
+	generics   map[string][]*genericBind      // generic bindings by family
+	setters    map[reflect.Type][]*setterBind // setters by type
+	instances  map[lookupKey]Binding          // bindings instantiated by generic bindings
+	pending    map[lookupKey]*sync.Once       // factory calls of generic bindings by instantiation
+	hooks      hooks                          // hooks called during resolution
+
+	deepInjection   bool // inject unexported fields and nested structs
+	strictShadowing bool // report bindings that shadow parent bindings without Override
+	fallback        bool // bindings may be shadowed by children in Strict mode
+	workers         int  // number of workers to solve eager bindings, if parallel
+	autoWiring      bool // resolve unbound interfaces with a unique assignable binding
+
+	cache layerCache // values derived from the configured layer
 }
 
 // newBindings creates and returns an initialized bindings object.
@@ -31,34 +292,123 @@ func newBindings(parent *bindings) *bindings {
 	}
 }
 
-func (bs *bindings) configure(bindings []Binding) (err error) {
-	bs.mut.Lock()
-	defer bs.mut.Unlock()
+// configure bindings and solve eager bindings in ctx.
+func (bs *bindings) configure(ctx context.Context, bindings []Binding) (err error) {
+	installations := install(bindings)
 
-	for _, b := range bindings {
-		if err = bs.configureBinding(b); err != nil {
-			return
+	if err = bs.configureBindings(installations); err != nil {
+		return
+	}
+
+	if err = bs.checkShadowing(installations); err != nil {
+		return
+	}
+
+	var eager []installation
+
+	for _, i := range installations {
+		if !i.b.eager() {
+			continue
+		}
+
+		// skip bindings that have been replaced by an override
+		if b, _ := bs.lookup(i.b.typ(), i.b.scope()); b == i.b {
+			eager = append(eager, i)
 		}
 	}
 
 	// initialize all eager bindings
-	for _, b := range bindings {
-		if !b.eager() {
-			continue
+	//
+	// Note that the lock must not be held here since solving
+	// a binding will look up its dependencies in bs.
+	if workers := bs.parallelism(); workers > 1 && len(eager) > 1 {
+		return bs.solveParallel(ctx, eager, workers)
+	}
+
+	for _, i := range eager {
+		if err = bs.solveEager(ctx, i); err != nil {
+			return
 		}
+	}
+
+	return
+}
+
+// solveEager solves the eager binding of the installation i in ctx.
+func (bs *bindings) solveEager(ctx context.Context, i installation) (err error) {
+	r := newResolution(ctx)
 
-		_, err = bs.solve(b)
+	if _, err = bs.solve(r, i.b, ""); err != nil {
+		err = resolutionError(err, i.b.typ(), i.b.scope(), nil)
+		r.failed(Event{Type: i.b.typ(), Key: i.b.scope(), Binding: i.b, Concrete: i.b, Err: err})
+		err = i.moduleError(err)
+	}
+
+	return
+}
+
+// configureBindings - configure all installed bindings.
+func (bs *bindings) configureBindings(installations []installation) (err error) {
+	bs.mut.Lock()
+	defer bs.mut.Unlock()
+
+	overridden := make(map[lookupKey]bool)
+
+	for _, i := range installations {
+		if c, ok := i.b.(bindingConfigurer); ok {
+			err = i.moduleError(c.configure(bs))
+		} else {
+			err = bs.configureBinding(i, overridden)
+		}
 
 		if err != nil {
 			return
 		}
+
+		if i.module != nil {
+			if bs.modules == nil {
+				bs.modules = make(map[Binding]*Module)
+			}
+
+			bs.modules[i.b] = i.module
+		}
 	}
 
 	return
 }
 
-// configureBinding - configure a single binding.
-func (bs *bindings) configureBinding(b Binding) (err error) {
+// checkShadowing of parent bindings by the installed bindings in Strict mode.
+func (bs *bindings) checkShadowing(installations []installation) error {
+	if bs.parent == nil || !bs.strict() {
+		return nil
+	}
+
+	var errs []error
+
+	for _, i := range installations {
+		if _, ok := i.b.(bindingConfigurer); ok || i.override {
+			continue
+		}
+
+		// skip bindings that have been replaced by an override
+		if b, _ := bs.lookup(i.b.typ(), i.b.scope()); b != i.b {
+			continue
+		}
+
+		if _, layer, found := findLayer(bs.parent, i.b.typ(), i.b.scope()); found && !bs.parent.at(layer).fallback {
+			errs = append(errs, i.moduleError(bindingError(ErrShadowed, i.b.typ(), i.b.scope())))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// configureBinding - configure a single installed binding.
+//
+// The keys that have been bound by an override in the same Configure call
+// are tracked in overridden. Other bindings for these keys are ignored.
+func (bs *bindings) configureBinding(i installation, overridden map[lookupKey]bool) (err error) {
+	b := i.b
 	typ := b.typ()
 	typeScope, loaded := bs.bindings[typ]
 
@@ -68,64 +418,181 @@ func (bs *bindings) configureBinding(b Binding) (err error) {
 	}
 
 	scope := b.scope()
+	key := lookupKey{t: typ, k: scope}
 
-	if _, loaded = typeScope[scope]; loaded {
-		if scope == "" {
-			err = fmt.Errorf("%w: %s", ErrDuplicate, typ)
-		} else {
-			err = fmt.Errorf(`%w: %s for "%s"`, ErrDuplicate, typ, scope)
+	if overridden[key] && !i.override {
+		return
+	}
+
+	if existing, loaded := typeScope[scope]; loaded && (!i.override || overridden[key]) {
+		err = bindingError(ErrDuplicate, typ, scope)
+
+		switch m := bs.modules[existing]; {
+		case m != nil && i.module != nil:
+			err = fmt.Errorf(`%w (module "%s", already bound by module "%s")`, err, i.module.name, m.name)
+		case m != nil:
+			err = fmt.Errorf(`%w (already bound by module "%s")`, err, m.name)
+		default:
+			err = i.moduleError(err)
 		}
 
 		return
 	}
 
 	typeScope[scope] = b
+	overridden[key] = overridden[key] || i.override
 
 	return
 }
 
+// normalizeScope k so that all variants of the empty scope are "".
+func normalizeScope(k string) string {
+	if k == scopeEmptyDash || k == scopeEmptyWildcard {
+		return ""
+	}
+
+	return k
+}
+
+// bindingError wraps err for type t and scope k.
+func bindingError(err error, t reflect.Type, k string) error {
+	if k == "" {
+		return fmt.Errorf("%w: %s", err, t)
+	}
+
+	return fmt.Errorf(`%w: %s for "%s"`, err, t, k)
+}
+
 // findBinding for type t and scope k in b and its parents.
 func findBinding(b *bindings, t reflect.Type, k string) (Binding, bool) {
-	for bb := b; bb != nil; bb = bb.parent {
-		bb.mut.RLock()
+	res, _, ok := findLayer(b, t, k)
+	return res, ok
+}
 
-		typeScope, loaded := bb.bindings[t]
+// at returns the bindings of the given layer, 0 for bs itself, 1 for its
+// parent, ...
+func (bs *bindings) at(layer int) *bindings {
+	for ; layer > 0; layer-- {
+		bs = bs.parent
+	}
 
-		if !loaded {
-			bb.mut.RUnlock()
-			continue
+	return bs
+}
+
+// findLayer finds a binding like findBinding and returns its layer,
+// 0 for b itself, 1 for its parent, ...
+//
+// Generic bindings are only consulted if there is no exact binding,
+// bindings assignable to t only with AutoWire.
+func findLayer(b *bindings, t reflect.Type, k string) (Binding, int, bool) {
+	layer := 0
+
+	for bb := b; bb != nil; bb = bb.parent {
+		if b, loaded := bb.lookup(t, k); loaded {
+			return b, layer, true
 		}
 
-		b, loaded := typeScope[k]
-		bb.mut.RUnlock()
+		layer++
+	}
 
-		if !loaded {
-			continue
+	if family, generic := familyOf(t); generic {
+		layer = 0
+
+		for bb := b; bb != nil; bb = bb.parent {
+			if b, loaded := bb.instantiate(family, t, k); loaded {
+				return b, layer, true
+			}
+
+			layer++
 		}
+	}
 
-		return b, true
+	if t.Kind() == reflect.Interface && b.settings().autoWire {
+		return b.autoWire(t, k)
 	}
 
-	return nil, false
+	return nil, 0, false
 }
 
-func (bs *bindings) get(t reflect.Type, k string) (res reflect.Value, err error) {
-	if k == scopeEmptyDash || k == scopeEmptyWildcard {
-		k = ""
+// lookup the binding for type t and scope k in bs only.
+func (bs *bindings) lookup(t reflect.Type, k string) (b Binding, loaded bool) {
+	bs.mut.RLock()
+	defer bs.mut.RUnlock()
+
+	b, loaded = bs.bindings[t][k]
+
+	return
+}
+
+// get a value for the dependency d.
+func (bs *bindings) get(r *resolution, d dependency) (res reflect.Value, err error) {
+	res, found, err := bs.resolve(r, d)
+
+	if !found && err == nil {
+		k := normalizeScope(d.scope)
+		err = resolutionError(bindingError(ErrNoSuchBinding, d.typ, k), d.typ, k, r.path(d.field))
+		r.failed(Event{Type: d.typ, Key: k, Field: d.field, Err: err})
 	}
 
-	binding, ok := findBinding(bs, t, k)
+	return
+}
 
-	if !ok {
-		if k == "" {
-			err = fmt.Errorf("%w: %s", ErrNoSuchBinding, t)
-		} else {
-			err = fmt.Errorf(`%w: %s for "%s"`, ErrNoSuchBinding, t, k)
+// resolve a value for the dependency d.
+//
+// The result is false if there is neither a binding, nor a contribution,
+// nor a configuration value for d.
+func (bs *bindings) resolve(r *resolution, d dependency) (res reflect.Value, ok bool, err error) {
+	start := r.now()
+	k := normalizeScope(d.scope)
+	found := bs.find(d.typ, k)
+	binding, ok := found.binding, found.found
+	e := Event{Type: d.typ, Key: k, Field: d.field, Binding: binding, Layer: found.layer}
+
+	defer func() {
+		if err != nil {
+			err = resolutionError(err, d.typ, k, r.path(d.field))
+		}
+
+		e.Duration, e.Err = since(start), err
+
+		if ok || err != nil {
+			r.emit(resolveHooks, e)
+		}
+
+		r.failed(e)
+	}()
+
+	if !ok && k == "" {
+		// collect contributions to slices and maps instead
+		if res, ok, err = bs.collect(r, d.typ, d.field); ok {
+			return
 		}
+	}
 
+	if !ok && k != "" {
+		// look up configuration values instead
+		if res, ok, err = bs.configValue(d.typ, k); ok {
+			return
+		}
+	}
+
+	if !ok {
+		return
+	}
+
+	e.Concrete = found.concrete
+
+	if res, err = bs.solve(r, e.Concrete, d.field); err != nil {
 		return
 	}
 
+	res, err = bs.decorate(r, e.Concrete, d.typ, k, res)
+
+	return
+}
+
+// concrete returns the most concrete binding for b.
+func (bs *bindings) concrete(binding Binding) Binding {
 	// find a more concrete binding
 	for {
 		to, ok := binding.(bindingTo)
@@ -144,62 +611,127 @@ func (bs *bindings) get(t reflect.Type, k string) (res reflect.Value, err error)
 		binding = better
 	}
 
-	res, err = bs.solve(binding)
-
-	return
+	return binding
 }
 
-func (bs *bindings) solve(b Binding) (res reflect.Value, err error) {
+// solve binding b which has been requested by field.
+func (bs *bindings) solve(r *resolution, b Binding, field string) (res reflect.Value, err error) {
+	if err = r.enter(b, field); err != nil {
+		return
+	}
+
+	defer r.leave()
+
 	var init bool
 
-	res, init, err = b.solve()
+	start := r.now()
+	res, init, err = b.solve(bs, r)
 
 	if err != nil {
 		return
 	}
 
 	if init {
-		err = bs.initialize(res.Type(), res)
+		r.instantiated(res, start)
+		err = bs.initialize(r, res.Type(), res)
+	}
+
+	return
+}
+
+// initialize value of type typ by injecting its fields and calling InitAfter.
+func (bs *bindings) initialize(r *resolution, typ reflect.Type, value reflect.Value) (err error) {
+	start := r.now()
+
+	defer func() {
+		r.emit(initializeHooks, Event{Type: typ, Concrete: r.current(), Duration: since(start), Err: err})
+	}()
+
+	if err = bs.inject(r, typ, value, bs.deep()); err != nil {
+		return
+	}
+
+	if err = bs.injectMethods(r, typ, value); err != nil {
+		return
+	}
+
+	if planOf(typ).initializer {
+		err = value.Interface().(Initializer).InitAfter()
 	}
 
 	return
 }
 
-func (bs *bindings) initialize(typ reflect.Type, value reflect.Value) (err error) {
-	switch typ.Kind() {
-	case reflect.Pointer:
-		if err = bs.initialize(typ.Elem(), value.Elem()); err != nil {
+// inject all fields with a bind struct tag of value of type typ.
+//
+// If deep is true, unexported fields are injected as well and nested
+// struct values, including embedded structs, are injected recursively.
+func (bs *bindings) inject(r *resolution, typ reflect.Type, value reflect.Value, deep bool) (err error) {
+	p := planOf(typ)
+
+	for i := 0; i < p.derefs; i++ {
+		if value.IsNil() {
 			return
 		}
-	case reflect.Struct:
-		numField := typ.NumField()
 
-		for fieldIndex := 0; fieldIndex < numField; fieldIndex++ {
-			field := value.Field(fieldIndex)
+		value = value.Elem()
+	}
 
-			if !field.CanSet() {
-				continue
-			}
+	for i := range p.fields {
+		fp := &p.fields[i]
 
-			fieldType := typ.Field(fieldIndex)
-			scope, inject := fieldType.Tag.Lookup(bindTag)
+		if fp.dep.deep && !deep {
+			continue
+		}
 
-			if !inject {
-				continue
-			}
+		field, ok := fp.field(value)
 
-			v, err := bs.get(fieldType.Type, scope)
+		if !ok {
+			continue
+		}
 
-			if err != nil {
-				return err
-			}
+		if fp.err != nil {
+			return fp.err
+		}
 
-			field.Set(v)
+		if fp.dep.handle != nil {
+			field.Set(bs.handle(r.context(), fp.dep))
+			continue
 		}
+
+		v, found, err := bs.resolveField(r, fp.dep)
+
+		if err != nil {
+			return err
+		}
+
+		if found {
+			field.Set(valueOf(field.Type(), fp.dep.box(v)))
+		}
+	}
+
+	return
+}
+
+// resolveField resolves the dependency d of a field.
+//
+// The result is false if d is optional and there is no binding.
+func (bs *bindings) resolveField(r *resolution, d dependency) (res reflect.Value, found bool, err error) {
+	if res, found, err = bs.resolve(r, d); found || err != nil {
+		return
 	}
 
-	if init, ok := value.Interface().(Initializer); ok {
-		err = init.InitAfter()
+	switch {
+	case d.optional:
+	case d.hasDefault:
+		res, err = d.defaultValue()
+		found = err == nil
+	default:
+		err = bindingError(ErrNoSuchBinding, d.typ, d.scope)
+	}
+
+	if err != nil {
+		err = resolutionError(err, d.typ, normalizeScope(d.scope), r.path(d.field))
 	}
 
 	return
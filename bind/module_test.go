@@ -1,6 +1,9 @@
 package bind
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -13,7 +16,7 @@ func TestModule_Get(t *testing.T) {
 
 	m := newBindings(nil)
 
-	err := m.configure([]Binding{
+	err := m.configure(context.Background(), []Binding{
 		Type[*T](),
 		Instance[string]("foo").For("foo"),
 		Instance[string]("bar"),
@@ -23,9 +26,111 @@ func TestModule_Get(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, err = m.get(typeOf[*T](), "")
+	_, err = m.get(newResolution(context.Background()), dependency{typ: typeOf[*T]()})
 
 	if err != nil {
 		t.Fatal(err)
 	}
 }
+
+func TestParseTag(t *testing.T) {
+	for tag, exp := range map[string]tagOptions{
+		"":                   {},
+		"-":                  {},
+		"*,optional":         {optional: true},
+		"cache,optional":     {scope: "cache", optional: true},
+		"port,default=5432":  {scope: "port", hasDefault: true, def: "5432"},
+		"-,default=a,b":      {hasDefault: true, def: "a,b"},
+		"name,default=":      {scope: "name", hasDefault: true},
+		"default=x":          {scope: "default=x"},
+		"x,default=optional": {scope: "x", hasDefault: true, def: "optional"},
+	} {
+		act, err := parseTag(tag)
+
+		if err != nil {
+			t.Errorf("%s: expected no error, got %s", tag, err)
+		} else if act != exp {
+			t.Errorf("%s: expected %+v, got %+v", tag, exp, act)
+		}
+	}
+
+	for _, tag := range []string{
+		"x,",
+		"x,,optional",
+		"x,foo",
+		"x,optional,optional",
+		"x,optional,default=1",
+		"x,Optional",
+	} {
+		if _, err := parseTag(tag); !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("%s: expected ErrInvalidTag, got %v", tag, err)
+		}
+	}
+}
+
+func TestModule_Install(t *testing.T) {
+	type Server struct {
+		Addr string `bind:"addr"`
+		DSN  string `bind:"dsn"`
+	}
+
+	logging := NewModule("logging",
+		Instance[string]("info").For("level"))
+
+	database := func(dsn string) *Module {
+		return NewModule("database",
+			logging,
+			Instance[string](dsn).For("dsn"))
+	}
+
+	http := NewModule("http",
+		logging,
+		Instance[string](":8080").For("addr"),
+		Once[*Server]())
+
+	ctx, err := Configure(context.Background(), database("postgres"), http)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := Get[*Server](ctx)
+
+	if s.Addr != ":8080" || s.DSN != "postgres" || For[string](ctx, "level") != "info" {
+		t.Errorf("unexpected server %+v", s)
+	}
+
+	d, err := Describe(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, b := range d.Bindings {
+		if b.Module == "" {
+			t.Errorf("expected a module for %s", b.label())
+		}
+	}
+}
+
+func TestModule_Duplicate(t *testing.T) {
+	a := NewModule("a", Instance[string]("a"))
+	b := NewModule("b", Instance[string]("b"))
+
+	for _, c := range []struct {
+		bindings []Binding
+		msg      string
+	}{
+		{[]Binding{a, b}, `(module "b", already bound by module "a")`},
+		{[]Binding{a, Instance[string]("c")}, `(already bound by module "a")`},
+		{[]Binding{Instance[string]("c"), b}, `(module "b")`},
+	} {
+		_, err := Configure(context.Background(), c.bindings...)
+
+		if !errors.Is(err, ErrDuplicate) {
+			t.Errorf("expected ErrDuplicate, got %v", err)
+		} else if !strings.HasSuffix(err.Error(), c.msg) {
+			t.Errorf("expected %s, got %s", c.msg, err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package bind
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Multi - Contribute Impl to all bindings of Iface.
+//
+// Unlike other bindings, there can be any number of contributions for
+// the same type. All contributions are collected when []Iface is requested,
+// e.g. by a field with a bind:"-" tag or with bind.All. Contributions for a
+// key, given with For, are also collected when map[string]Iface is requested.
+//
+// Contributions of parent contexts are collected first. A keyed contribution
+// replaces a contribution of a parent context with the same key.
+//
+// Impl is resolved like an Implementation binding. If Impl is bound
+// itself that binding is used, otherwise new instances of Impl are created.
+//
+// An explicit binding for []Iface or map[string]Iface takes precedence
+// over the contributions.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.Multi[HealthCheck, *DBCheck]().For("db"),
+//    bind.Multi[HealthCheck, *CacheCheck]().For("cache"))
+//
+//  type Health struct {
+//    All    []HealthCheck          `bind:"-"` // [*DBCheck, *CacheCheck]
+//    ByName map[string]HealthCheck `bind:"-"` // {"db": *DBCheck, "cache": *CacheCheck}
+//  }
+func Multi[Iface, Impl any]() Binding {
+	mustBeAssignable[Iface, Impl]()
+	return &multiBind{
+		b: &typeBind[Iface, Impl]{
+			typeFrom: typeOf[Iface](),
+			typeTo:   typeOf[Impl](),
+		},
+	}
+}
+
+// All returns instances of all contributions to V in the current context.
+//
+// This method panics if any contribution can't be resolved. Use TryAll
+// to work with the error instead.
+func All[V any](ctx context.Context) []V {
+	res, err := TryAll[V](ctx)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// TryAll returns instances of all contributions to V or an error.
+//
+// The result is empty if there are no contributions to V.
+func TryAll[V any](ctx context.Context) (res []V, err error) {
+	b, loaded := fromCtx(ctx)
+	t := typeOf[[]V]()
+
+	if !loaded {
+		err = fmt.Errorf("%w: for type %s", ErrContextWithoutBindings, t)
+		return
+	}
+
+	r := newResolution(ctx)
+	v, ok, err := b.collect(r, t, "")
+
+	if err != nil {
+		err = resolutionError(err, t, "", nil)
+		r.failed(Event{Type: t, Err: err})
+		return
+	}
+
+	if !ok {
+		return
+	}
+
+	res = v.Interface().([]V)
+
+	return
+}
+
+// multiBind represents a contribution to all bindings of a type.
+type multiBind struct {
+	key string
+	b   Binding
+}
+
+func (b *multiBind) typ() reflect.Type { return b.b.typ() }
+func (b *multiBind) scope() string     { return b.key }
+func (b *multiBind) eager() bool       { return false }
+func (b *multiBind) kind() Kind        { return KindMulti }
+
+func (b *multiBind) solve(bs *bindings, r *resolution) (reflect.Value, bool, error) {
+	return b.b.solve(bs, r)
+}
+
+func (b *multiBind) For(k string) Binding {
+	b.key = k
+	return b
+}
+
+func (b *multiBind) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
+
+func (b *multiBind) configure(bs *bindings) (err error) {
+	t := b.typ()
+
+	if b.key != "" {
+		for _, c := range bs.multi[t] {
+			if c.key == b.key {
+				return fmt.Errorf(`%w: contribution to %s for "%s"`, ErrDuplicate, t, b.key)
+			}
+		}
+	}
+
+	if bs.multi == nil {
+		bs.multi = make(map[reflect.Type][]*multiBind)
+	}
+
+	bs.multi[t] = append(bs.multi[t], b)
+
+	return
+}
+
+// contributions to the collection type t in bs and its parents.
+//
+// Only slices and maps with string keys can be collected. Contributions
+// without a key are ignored for maps. The result is ordered from the root
+// to bs, keyed contributions of children replace those of their parents.
+func (bs *bindings) contributions(t reflect.Type) (res []*multiBind) {
+	keyed := false
+
+	switch {
+	case t.Kind() == reflect.Slice:
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+		keyed = true
+	default:
+		return
+	}
+
+	seen := make(map[string]bool)
+
+	for bb := bs; bb != nil; bb = bb.parent {
+		var layer []*multiBind
+
+		bb.mut.RLock()
+
+		for _, c := range bb.multi[t.Elem()] {
+			if c.key == "" && keyed || c.key != "" && seen[c.key] {
+				continue
+			}
+
+			layer = append(layer, c)
+		}
+
+		bb.mut.RUnlock()
+
+		for _, c := range layer {
+			seen[c.key] = true
+		}
+
+		res = append(layer, res...)
+	}
+
+	return
+}
+
+// collect all contributions to the collection type t for field.
+//
+// The result is false if there are no contributions.
+func (bs *bindings) collect(r *resolution, t reflect.Type, field string) (res reflect.Value, ok bool, err error) {
+	contributions := bs.contributions(t)
+
+	if len(contributions) == 0 {
+		return
+	}
+
+	ok = true
+
+	if t.Kind() == reflect.Slice {
+		res = reflect.MakeSlice(t, 0, len(contributions))
+	} else {
+		res = reflect.MakeMapWithSize(t, len(contributions))
+	}
+
+	for _, c := range contributions {
+		var v reflect.Value
+
+		if v, err = bs.solve(r, bs.concrete(c.b), field); err != nil {
+			return
+		}
+
+		if v, err = bs.decorate(r, bs.concrete(c.b), t.Elem(), "", valueOf(t.Elem(), v)); err != nil {
+			return
+		}
+
+		if t.Kind() == reflect.Slice {
+			res = reflect.Append(res, v)
+		} else {
+			res.SetMapIndex(reflect.ValueOf(c.key).Convert(t.Key()), v)
+		}
+	}
+
+	return
+}
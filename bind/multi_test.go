@@ -0,0 +1,109 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type plugin interface {
+	Name() string
+}
+
+type pluginA struct{}
+
+func (p *pluginA) Name() string { return "a" }
+
+type pluginB struct {
+	Suffix string `bind:"suffix"`
+}
+
+func (p *pluginB) Name() string { return "b" + p.Suffix }
+
+type pluginHost struct {
+	All    []plugin          `bind:"-"`
+	ByName map[string]plugin `bind:"-"`
+}
+
+func names(plugins []plugin) (res []string) {
+	for _, p := range plugins {
+		res = append(res, p.Name())
+	}
+	return
+}
+
+func TestMulti(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("!").For("suffix"),
+		bind.Multi[plugin, *pluginA]().For("a"),
+		bind.Multi[plugin, *pluginB]().For("b"),
+		bind.Multi[plugin, *pluginA]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := names(bind.All[plugin](ctx)); len(act) != 3 || act[0] != "a" || act[1] != "b!" || act[2] != "a" {
+		t.Errorf("expected [a b! a], got %v", act)
+	}
+
+	host := bind.New[*pluginHost](ctx)
+
+	if len(host.All) != 3 {
+		t.Errorf("expected three plugins, got %v", names(host.All))
+	}
+
+	if len(host.ByName) != 2 || host.ByName["a"].Name() != "a" || host.ByName["b"].Name() != "b!" {
+		t.Errorf("expected two keyed plugins, got %v", host.ByName)
+	}
+
+	// children contribute as well and replace keyed contributions
+	child, err := bind.Configure(ctx,
+		bind.String("?").For("suffix"),
+		bind.Multi[plugin, *pluginB]().For("a"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := names(bind.All[plugin](child)); len(act) != 3 || act[0] != "b?" || act[1] != "a" || act[2] != "b?" {
+		t.Errorf("expected [b? a b?], got %v", act)
+	}
+
+	if act := bind.New[*pluginHost](child).ByName["a"].Name(); act != "b?" {
+		t.Errorf("expected b?, got %s", act)
+	}
+
+	if err = bind.Validate(child); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+}
+
+func TestMultiEmpty(t *testing.T) {
+	ctx, err := bind.Configure(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := bind.All[plugin](ctx); len(act) != 0 {
+		t.Errorf("expected no plugins, got %v", act)
+	}
+
+	if _, err = bind.TryNew[*pluginHost](ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %s", err)
+	}
+
+	_, err = bind.Configure(ctx,
+		bind.Multi[plugin, *pluginA]().For("a"),
+		bind.Multi[plugin, *pluginB]().For("a"))
+
+	if !errors.Is(err, bind.ErrDuplicate) {
+		t.Errorf("expected ErrDuplicate, got %s", err)
+	}
+}
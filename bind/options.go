@@ -0,0 +1,112 @@
+package bind
+
+import "reflect"
+
+// Deep - Inject unexported fields and nested structs.
+//
+// This is an option of Configure. By default only exported fields
+// with a bind struct tag are injected. With Deep, unexported fields
+// with a bind struct tag are injected as well. Fields of struct values
+// without a bind struct tag, like embedded structs, are injected
+// recursively.
+//
+// The option applies to all instances initialized in the configured
+// context and its children.
+//
+// Example
+//
+//  type Base struct {
+//    log *Logger `bind:"-"`
+//  }
+//
+//  type Service struct {
+//    Base
+//    db Database `bind:"-"`
+//  }
+//
+//  ctx, _ = bind.Configure(ctx, bind.Deep())
+//  svc := bind.New[*Service](ctx) // svc.db and svc.log are injected
+func Deep() Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.deepInjection = true
+			return nil
+		},
+	}
+}
+
+// optionBind is an option that configures the bindings of a context.
+type optionBind struct {
+	apply func(bs *bindings) error
+}
+
+func (b *optionBind) typ() reflect.Type { return nil }
+func (b *optionBind) scope() string     { return "" }
+func (b *optionBind) eager() bool       { return false }
+
+func (b *optionBind) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	panic("bind: can't solve an option")
+}
+
+func (b *optionBind) For(string) Binding { return b }
+func (b *optionBind) As(any) Binding     { return b }
+
+func (b *optionBind) configure(bs *bindings) error { return b.apply(bs) }
+
+// Strict - Report bindings that shadow bindings of a parent context.
+//
+// This is an option of Configure. Bindings of the configured context and
+// its children that shadow a binding of a parent context for the same type
+// and key fail with ErrShadowed, unless they are wrapped with Override.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx, bind.Strict(),
+//    bind.Instance[string]("localhost").For("host"))
+//
+//  _, err := bind.Configure(ctx,
+//    bind.Instance[string]("remote").For("host")) // ErrShadowed
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.Override(bind.Instance[string]("remote").For("host"))) // ok
+func Strict() Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.strictShadowing = true
+			return nil
+		},
+	}
+}
+
+// Fallback - Allow child contexts to shadow the bindings of a context.
+//
+// This is an option of Configure. Bindings of child contexts that shadow
+// a binding of the configured context aren't reported in Strict mode, as
+// if they were wrapped with Override. The option applies to the bindings
+// of the configured context only, not to those of its parents.
+//
+// Example
+//
+//  defaults, _ := bind.Configure(ctx, bind.Fallback(),
+//    bind.Implementation[Mailer, *noopMailer]())
+//
+//  ctx, _ = bind.Configure(defaults, bind.Strict(),
+//    bind.Implementation[Mailer, *smtpMailer]()) // ok
+func Fallback() Binding {
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.fallback = true
+			return nil
+		},
+	}
+}
+
+// deep is true if bs or any of its parents enabled Deep.
+func (bs *bindings) deep() bool {
+	return bs.settings().deep
+}
+
+// strict is true if bs or any of its parents enabled Strict.
+func (bs *bindings) strict() bool {
+	return bs.settings().strict
+}
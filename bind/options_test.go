@@ -0,0 +1,213 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type deepBase struct {
+	name string `bind:"name"`
+}
+
+type deepNested struct {
+	Port int `bind:"port"`
+}
+
+type deepService struct {
+	deepBase
+	Nested deepNested
+	host   string `bind:"host"`
+	skip   string
+}
+
+func TestDeep(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("svc").For("name"),
+		bind.String("localhost").For("host"),
+		bind.Int(8080).For("port"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	svc := bind.New[*deepService](ctx)
+
+	if svc.name != "" || svc.host != "" || svc.Nested.Port != 0 {
+		t.Errorf("expected no injection without Deep, got %+v", svc)
+	}
+
+	ctx, err = bind.Configure(ctx, bind.Deep())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	svc = bind.New[*deepService](ctx)
+
+	if svc.name != "svc" || svc.host != "localhost" || svc.Nested.Port != 8080 || svc.skip != "" {
+		t.Errorf("unexpected deep injection %+v", svc)
+	}
+}
+
+func TestDeep_Validate(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Once[*deepService]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error without Deep, got %s", err)
+	}
+
+	ctx, err = bind.Configure(ctx, bind.Deep())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %v", err)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	created := 0
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Instance[string]("a").For("host"),
+		bind.Override(bind.Instance[string]("b").For("host")),
+		bind.ProviderCtx[int](func(context.Context) (int, error) {
+			created++
+			return 1, nil
+		}),
+		bind.Override(bind.NewModule("test", bind.Instance[int](2))))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if v := bind.For[string](ctx, "host"); v != "b" {
+		t.Errorf("expected b, got %s", v)
+	}
+
+	if v := bind.Get[int](ctx); v != 2 || created != 0 {
+		t.Errorf("expected 2 without calling the provider, got %d", v)
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.Override(bind.Instance[string]("c").For("host")),
+		bind.Instance[string]("d").For("host"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if v := bind.For[string](child, "host"); v != "c" {
+		t.Errorf("expected the override to win regardless of order, got %s", v)
+	}
+
+	_, err = bind.Configure(ctx,
+		bind.Override(bind.Instance[string]("e").For("host")),
+		bind.Instance[string]("f").For("host"),
+		bind.Override(bind.Instance[string]("g").For("host")))
+
+	if !errors.Is(err, bind.ErrDuplicate) {
+		t.Errorf("expected ErrDuplicate for two overrides, got %v", err)
+	}
+}
+
+func TestOverride_Eager(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Once[*deepService](),
+		bind.Deep(),
+		bind.Override(bind.Instance[*deepService](&deepService{})))
+
+	if err != nil {
+		t.Errorf("expected the replaced Once binding not to be solved, got %s", err)
+		return
+	}
+
+	if svc := bind.Get[*deepService](ctx); svc.Nested.Port != 0 {
+		t.Errorf("expected the override, got %+v", svc)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Strict(),
+		bind.Instance[string]("localhost").For("host"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	_, err = bind.Configure(ctx,
+		bind.Instance[string]("remote").For("host"))
+
+	if !errors.Is(err, bind.ErrShadowed) {
+		t.Errorf("expected ErrShadowed, got %v", err)
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.Override(bind.Instance[string]("remote").For("host")),
+		bind.Instance[string]("replaced").For("host"),
+		bind.Instance[string]("new").For("other"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if v := bind.For[string](child, "host"); v != "remote" {
+		t.Errorf("expected remote, got %s", v)
+	}
+
+	if _, err = bind.Configure(context.Background(), bind.Instance[string]("a")); err != nil {
+		t.Errorf("expected no error without Strict, got %s", err)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	defaults, err := bind.Configure(context.Background(),
+		bind.Fallback(),
+		bind.Instance[string]("default").For("host"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	ctx, err := bind.Configure(defaults,
+		bind.Strict(),
+		bind.Instance[string]("localhost").For("host"))
+
+	if err != nil {
+		t.Fatalf("expected the fallback to be shadowed, got %s", err)
+		return
+	}
+
+	if v := bind.For[string](ctx, "host"); v != "localhost" {
+		t.Errorf("expected localhost, got %s", v)
+	}
+
+	// the option doesn't apply to the children of the fallback context
+	if _, err = bind.Configure(ctx, bind.Instance[string]("remote").For("host")); !errors.Is(err, bind.ErrShadowed) {
+		t.Errorf("expected ErrShadowed, got %v", err)
+	}
+}
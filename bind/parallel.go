@@ -0,0 +1,224 @@
+package bind
+
+import (
+	"context"
+	"errors"
+	"runtime"
+)
+
+// Parallel - Initialize eager bindings concurrently.
+//
+// This is an option of Configure. Eager bindings, like Once, of the
+// configured context and its children are initialized by up to workers
+// goroutines. If workers isn't positive, runtime.GOMAXPROCS(0) is used.
+//
+// Eager bindings are ordered by their dependencies, hence a binding is
+// initialized after all eager bindings it depends on. Independent
+// bindings are initialized concurrently, which is useful if InitAfter
+// does I/O like connecting to a database.
+//
+// If a binding fails, the bindings that depend on it aren't initialized.
+// No further bindings are initialized once the context passed to
+// Configure is done. All errors are returned at once.
+//
+// Example
+//
+//  ctx, err := bind.Configure(ctx,
+//    bind.Parallel(4),
+//    bind.ImplementationOnce[Database, *sqlDBImpl](), // both connect
+//    bind.ImplementationOnce[Cache, *redisCache](),   // at the same time
+//  )
+func Parallel(workers int) Binding {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return &optionBind{
+		apply: func(bs *bindings) error {
+			bs.workers = workers
+			return nil
+		},
+	}
+}
+
+// parallelism is the number of workers of the nearest bindings that
+// enabled Parallel, 0 if none did.
+func (bs *bindings) parallelism() int {
+	return bs.settings().workers
+}
+
+// eagerNode is an eager binding in the dependency graph of solveParallel.
+type eagerNode struct {
+	i          installation
+	pending    int   // number of dependencies that haven't been solved yet
+	dependents []int // nodes that depend on this node
+}
+
+// solveParallel solves the eager bindings by up to workers goroutines in
+// the order of their dependencies.
+//
+// If the dependencies contain a cycle, the bindings are solved
+// sequentially instead to report the cycle.
+func (bs *bindings) solveParallel(ctx context.Context, eager []installation, workers int) error {
+	nodes, ok := bs.eagerGraph(eager)
+
+	if !ok {
+		for _, i := range eager {
+			if err := bs.solveEager(ctx, i); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	type result struct {
+		node int
+		err  error
+	}
+
+	var (
+		errs    []error
+		running int
+		sem     = make(chan struct{}, workers)
+		results = make(chan result)
+	)
+
+	start := func(n int) {
+		running++
+
+		go func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results <- result{node: n, err: bs.solveEager(ctx, nodes[n].i)}
+		}()
+	}
+
+	for n := range nodes {
+		if nodes[n].pending == 0 && ctx.Err() == nil {
+			start(n)
+		}
+	}
+
+	for running > 0 {
+		res := <-results
+		running--
+
+		if res.err != nil {
+			errs = append(errs, res.err)
+			continue
+		}
+
+		// don't start any more bindings once the context is done
+		if ctx.Err() != nil {
+			continue
+		}
+
+		for _, d := range nodes[res.node].dependents {
+			if nodes[d].pending--; nodes[d].pending == 0 {
+				start(d)
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// eagerGraph returns the dependency graph of the eager bindings.
+//
+// Edges are dependencies on other eager bindings, either directly or via
+// bindings that aren't eager. The result is false if the graph contains
+// a cycle.
+func (bs *bindings) eagerGraph(eager []installation) (nodes []eagerNode, ok bool) {
+	nodes = make([]eagerNode, len(eager))
+	indices := make(map[Binding]int, len(eager))
+
+	for n, i := range eager {
+		nodes[n].i = i
+		indices[i.b] = n
+	}
+
+	for n, i := range eager {
+		for d := range bs.eagerDeps(i.b, indices) {
+			nodes[n].pending++
+			nodes[d].dependents = append(nodes[d].dependents, n)
+		}
+	}
+
+	// Kahn's algorithm to detect cycles
+	pending := make([]int, len(nodes))
+	queue := make([]int, 0, len(nodes))
+
+	for n := range nodes {
+		if pending[n] = nodes[n].pending; pending[n] == 0 {
+			queue = append(queue, n)
+		}
+	}
+
+	for k := 0; k < len(queue); k++ {
+		for _, d := range nodes[queue[k]].dependents {
+			if pending[d]--; pending[d] == 0 {
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	ok = len(queue) == len(nodes)
+
+	return
+}
+
+// eagerDeps returns the indices of the eager bindings b depends on.
+func (bs *bindings) eagerDeps(b Binding, indices map[Binding]int) map[int]bool {
+	res := make(map[int]bool)
+	visited := make(map[Binding]bool)
+
+	var walk func(b Binding)
+
+	walk = func(b Binding) {
+		d, ok := bs.concrete(b).(bindingDeps)
+
+		if !ok || visited[b] {
+			return
+		}
+
+		visited[b] = true
+
+		// errors are reported when the binding is solved
+		deps, _ := d.deps()
+		deep := bs.deep()
+
+		for _, dep := range deps {
+			if dep.handle != nil || dep.deep && !deep {
+				continue
+			}
+
+			var next []Binding
+
+			if found, ok := findBinding(bs, dep.typ, normalizeScope(dep.scope)); ok {
+				next = append(next, bs.concrete(found))
+			} else if dep.scope == "" {
+				for _, c := range bs.contributions(dep.typ) {
+					next = append(next, bs.concrete(c.b))
+				}
+			}
+
+			for _, nb := range next {
+				if n, ok := indices[nb]; ok {
+					res[n] = true
+				} else {
+					walk(nb)
+				}
+			}
+		}
+	}
+
+	walk(b)
+
+	return res
+}
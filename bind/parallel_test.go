@@ -0,0 +1,159 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joa/goety/bind"
+)
+
+// parallelBarrier is passed by all parallel services that must be
+// initialized concurrently.
+type parallelBarrier struct {
+	wg sync.WaitGroup
+}
+
+func (b *parallelBarrier) await() error {
+	b.wg.Done()
+
+	done := make(chan struct{})
+
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(5 * time.Second):
+		return errors.New("not initialized concurrently")
+	}
+}
+
+type parallelDB struct {
+	Barrier *parallelBarrier `bind:"-"`
+}
+
+func (s *parallelDB) InitAfter() error { return s.Barrier.await() }
+
+type parallelCache struct {
+	Barrier *parallelBarrier `bind:"-"`
+}
+
+func (s *parallelCache) InitAfter() error { return s.Barrier.await() }
+
+type parallelServer struct {
+	DB    *parallelDB    `bind:"-"`
+	Cache *parallelCache `bind:"-"`
+	Order *[]string      `bind:"-"`
+}
+
+func (s *parallelServer) InitAfter() error {
+	*s.Order = append(*s.Order, "server")
+	return nil
+}
+
+func TestParallel(t *testing.T) {
+	barrier := &parallelBarrier{}
+	barrier.wg.Add(2)
+
+	var order []string
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.Parallel(2),
+		bind.Instance[*parallelBarrier](barrier),
+		bind.Instance[*[]string](&order),
+		bind.Once[*parallelServer](),
+		bind.Once[*parallelDB](),
+		bind.Once[*parallelCache]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	server := bind.Get[*parallelServer](ctx)
+
+	if server.DB != bind.Get[*parallelDB](ctx) || server.Cache != bind.Get[*parallelCache](ctx) {
+		t.Error("expected the server to share the instances")
+	}
+
+	if len(order) != 1 {
+		t.Errorf("expected the server to be initialized once, got %v", order)
+	}
+}
+
+type parallelFailure struct {
+	Err error `bind:"-"`
+}
+
+func (s *parallelFailure) InitAfter() error { return s.Err }
+
+type parallelOther struct {
+	Err error `bind:"other"`
+}
+
+func (s *parallelOther) InitAfter() error { return s.Err }
+
+type parallelDependent struct {
+	Failure *parallelFailure `bind:"-"`
+}
+
+func (s *parallelDependent) InitAfter() error {
+	panic("must not be initialized")
+}
+
+func TestParallel_Errors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+
+	_, err := bind.Configure(context.Background(),
+		bind.Parallel(2),
+		bind.Instance[error](errA),
+		bind.Instance[error](errB).For("other"),
+		bind.Once[*parallelDependent](),
+		bind.Once[*parallelFailure](),
+		bind.Once[*parallelOther]())
+
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("expected both errors, got %v", err)
+	}
+}
+
+func TestParallel_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := bind.Configure(ctx,
+		bind.Parallel(2),
+		bind.Instance[*[]string](&[]string{}),
+		bind.Once[*parallelServer](),
+		bind.Once[*parallelDB](),
+		bind.Once[*parallelCache]())
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+type parallelCycleA struct {
+	B *parallelCycleB `bind:"-"`
+}
+
+type parallelCycleB struct {
+	A *parallelCycleA `bind:"-"`
+}
+
+func TestParallel_Cycle(t *testing.T) {
+	_, err := bind.Configure(context.Background(),
+		bind.Parallel(2),
+		bind.Once[*parallelCycleA](),
+		bind.Once[*parallelCycleB]())
+
+	if !errors.Is(err, bind.ErrCycle) {
+		t.Errorf("expected ErrCycle, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package bind
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+var initializerType = typeOf[Initializer]()
+
+// typePlans caches the injection plans of all types by reflect.Type.
+var typePlans sync.Map
+
+// typePlan is the injection plan of a type.
+//
+// Plans only depend on the type itself and are computed once. The
+// bindings of the fields are looked up with the cache of each layer.
+type typePlan struct {
+	derefs      int          // number of pointers to dereference to get to the struct
+	fields      []fieldPlan  // fields to inject in order, including nested fields
+	methods     []methodPlan // Inject methods to call in order
+	initializer bool         // the type implements Initializer
+}
+
+// fieldPlan is the injection plan of a single field.
+type fieldPlan struct {
+	index []int // index sequence of the field, see reflect.Value.FieldByIndex
+	dep   dependency
+	err   error // invalid bind struct tag, if any
+}
+
+// planOf returns the cached injection plan of type t.
+func planOf(t reflect.Type) *typePlan {
+	if p, ok := typePlans.Load(t); ok {
+		return p.(*typePlan)
+	}
+
+	p := &typePlan{initializer: t.Implements(initializerType), methods: planMethods(t)}
+	st := t
+
+	for st.Kind() == reflect.Pointer {
+		st = st.Elem()
+		p.derefs++
+	}
+
+	if st.Kind() == reflect.Struct {
+		p.fields = planFields(st, nil, false)
+	}
+
+	actual, _ := typePlans.LoadOrStore(t, p)
+
+	return actual.(*typePlan)
+}
+
+// planFields returns the plans of the fields of the struct type t.
+//
+// Fields of nested struct values without a bind struct tag are planned
+// recursively. Like unexported fields they are only injected with Deep.
+func planFields(t reflect.Type, index []int, nested bool) (res []fieldPlan) {
+	numField := t.NumField()
+
+	for fieldIndex := 0; fieldIndex < numField; fieldIndex++ {
+		field := t.Field(fieldIndex)
+		fieldIndex := append(index[:len(index):len(index)], fieldIndex)
+		d, inject, err := fieldDependency(t, field)
+
+		if !inject {
+			if field.Type.Kind() == reflect.Struct {
+				res = append(res, planFields(field.Type, fieldIndex, true)...)
+			}
+
+			continue
+		}
+
+		d.deep = nested || !field.IsExported()
+		res = append(res, fieldPlan{index: fieldIndex, dep: d, err: err})
+	}
+
+	return
+}
+
+// field of the struct value v for the plan; unexported fields are made
+// settable.
+//
+// The result is false if the field can't be set.
+func (p *fieldPlan) field(v reflect.Value) (reflect.Value, bool) {
+	for _, i := range p.index {
+		v = v.Field(i)
+
+		if !v.CanSet() {
+			if !v.CanAddr() {
+				return v, false
+			}
+
+			v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+		}
+	}
+
+	return v, true
+}
+
+// layerCache caches values that are derived from a layer and its parents.
+//
+// The bindings of a layer don't change once it has been configured. A
+// child context is configured as a new layer with an empty cache, hence
+// cached values never have to be invalidated. The cache must not be used
+// before the layer has been configured.
+type layerCache struct {
+	once     sync.Once
+	settings layerSettings
+	lookups  sync.Map // of lookupKey to *lookupResult
+}
+
+// layerSettings are the options of a layer and its parents.
+type layerSettings struct {
+	deep     bool
+	strict   bool
+	autoWire bool
+	setters  bool // any layer has setters
+	workers  int
+	hooks    hooks // hooks ordered from the root to the layer
+}
+
+// lookupKey of a binding.
+type lookupKey struct {
+	t reflect.Type
+	k string
+}
+
+// lookupResult of a binding of a layer and its parents.
+type lookupResult struct {
+	binding  Binding // the binding for the key, if found
+	concrete Binding // the most concrete binding for binding
+	layer    int
+	found    bool
+}
+
+// settings of bs and its parents.
+func (bs *bindings) settings() *layerSettings {
+	c := &bs.cache
+
+	c.once.Do(func() {
+		s := &c.settings
+
+		for bb := bs; bb != nil; bb = bb.parent {
+			bb.mut.RLock()
+
+			s.deep = s.deep || bb.deepInjection
+			s.strict = s.strict || bb.strictShadowing
+			s.autoWire = s.autoWire || bb.autoWiring
+			s.setters = s.setters || len(bb.setters) > 0
+
+			if s.workers == 0 {
+				s.workers = bb.workers
+			}
+
+			s.hooks.resolve = append(append([]hookFunc(nil), bb.hooks.resolve...), s.hooks.resolve...)
+			s.hooks.instantiate = append(append([]hookFunc(nil), bb.hooks.instantiate...), s.hooks.instantiate...)
+			s.hooks.initialize = append(append([]hookFunc(nil), bb.hooks.initialize...), s.hooks.initialize...)
+			s.hooks.error = append(append([]hookFunc(nil), bb.hooks.error...), s.hooks.error...)
+
+			bb.mut.RUnlock()
+		}
+	})
+
+	return &c.settings
+}
+
+// find the binding of type t for key k in bs or its parents.
+//
+// Like findLayer, but the result is cached together with the most
+// concrete binding.
+func (bs *bindings) find(t reflect.Type, k string) *lookupResult {
+	key := lookupKey{t: t, k: k}
+
+	if res, ok := bs.cache.lookups.Load(key); ok {
+		return res.(*lookupResult)
+	}
+
+	res := &lookupResult{}
+	res.binding, res.layer, res.found = findLayer(bs, t, k)
+
+	if res.found {
+		res.concrete = bs.concrete(res.binding)
+	}
+
+	actual, _ := bs.cache.lookups.LoadOrStore(key, res)
+
+	return actual.(*lookupResult)
+}
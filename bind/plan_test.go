@@ -0,0 +1,42 @@
+package bind_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type planService struct {
+	Host string `bind:"host"`
+}
+
+func TestPlan_ChildLayer(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("localhost").For("host"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if svc := bind.New[*planService](ctx); svc.Host != "localhost" {
+		t.Errorf("expected localhost, got %s", svc.Host)
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.String("remote").For("host"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if svc := bind.New[*planService](child); svc.Host != "remote" {
+		t.Errorf("expected the child binding, got %s", svc.Host)
+	}
+
+	if svc := bind.New[*planService](ctx); svc.Host != "localhost" {
+		t.Errorf("expected the parent binding to stay cached, got %s", svc.Host)
+	}
+}
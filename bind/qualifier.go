@@ -0,0 +1,110 @@
+package bind
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Named holds an instance of T that is qualified by the type Q.
+//
+// Fields of type Named are injected with the binding of T that has been
+// qualified with As and a value of type Q. A bind struct tag is optional
+// but can be used for the optional and default options. Constructor
+// parameters of type Named are resolved the same way.
+//
+// Qualifiers are usually empty struct types. As opposed to the keys of
+// For they can't be misspelled since they are checked by the compiler.
+//
+// Example
+//
+//  type PrimaryDB struct{}
+//  type ReplicaDB struct{}
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.Instance[string]("postgres://primary").As(PrimaryDB{}),
+//    bind.Instance[string]("postgres://replica").As(ReplicaDB{}))
+//
+//  type Repository struct {
+//    Primary bind.Named[string, PrimaryDB]
+//    Replica bind.Named[string, ReplicaDB] `bind:",optional"`
+//  }
+//
+//  repo := bind.New[*Repository](ctx)
+//  fmt.Println(repo.Primary.Get()) // "postgres://primary"
+type Named[T, Q any] struct {
+	value T
+}
+
+// Get returns the instance of T.
+func (n Named[T, Q]) Get() T {
+	return n.value
+}
+
+func (n *Named[T, Q]) qualified() (reflect.Type, string) {
+	return typeOf[T](), qualifier(typeOf[Q]())
+}
+
+func (n *Named[T, Q]) set(v reflect.Value) {
+	n.value = unboxValue[T](typeOf[T](), v)
+}
+
+// Qualifier returns the key of bindings qualified by Q.
+//
+// The key can be used wherever keys are accepted, e.g. with bind.For
+// to resolve a qualified binding directly.
+func Qualifier[Q any]() string {
+	return qualifier(typeOf[Q]())
+}
+
+// qualifiedValue is implemented by pointers to Named.
+type qualifiedValue interface {
+	// qualified returns the type and the key of the qualified binding.
+	qualified() (reflect.Type, string)
+
+	// set the instance to v.
+	set(v reflect.Value)
+}
+
+var qualifiedValueType = typeOf[qualifiedValue]()
+
+// qualifiedDependency returns the dependency of type t if it's a Named type.
+func qualifiedDependency(t reflect.Type) (d dependency, ok bool) {
+	if ok = reflect.PointerTo(t).Implements(qualifiedValueType); ok {
+		d.typ, d.scope = reflect.New(t).Interface().(qualifiedValue).qualified()
+		d.named = t
+	}
+
+	return
+}
+
+// paramDependency of a constructor on a parameter of type t.
+func paramDependency(t reflect.Type) dependency {
+	if d, ok := qualifiedDependency(t); ok {
+		return d
+	}
+
+	return dependency{typ: t}
+}
+
+// qualifierOf returns the key of bindings qualified by the type of q.
+func qualifierOf(q any) string {
+	t := reflect.TypeOf(q)
+
+	if t == nil {
+		panic("bind: qualifier must not be nil")
+	}
+
+	return qualifier(t)
+}
+
+// qualifier returns the key of bindings qualified by the type t.
+//
+// Keys of qualifiers start with "@" followed by the package path
+// and the name of t.
+func qualifier(t reflect.Type) string {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return "@" + t.String()
+	}
+
+	return fmt.Sprintf("@%s.%s", t.PkgPath(), t.Name())
+}
@@ -0,0 +1,105 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type primaryDB struct{}
+
+type replicaDB struct{}
+
+type qualifiedRepository struct {
+	Primary bind.Named[string, primaryDB]
+	Replica bind.Named[string, replicaDB] `bind:",optional"`
+	Port    bind.Named[int, primaryDB]    `bind:",default=5432"`
+}
+
+func TestNamed(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Instance[string]("primary").As(primaryDB{}),
+		bind.Instance[string]("unqualified"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	repo := bind.New[*qualifiedRepository](ctx)
+
+	if repo.Primary.Get() != "primary" {
+		t.Errorf("expected primary, got %s", repo.Primary.Get())
+	}
+
+	if repo.Replica.Get() != "" {
+		t.Errorf("expected no replica, got %s", repo.Replica.Get())
+	}
+
+	if repo.Port.Get() != 5432 {
+		t.Errorf("expected default 5432, got %d", repo.Port.Get())
+	}
+
+	if v := bind.For[string](ctx, bind.Qualifier[primaryDB]()); v != "primary" {
+		t.Errorf("expected primary, got %s", v)
+	}
+}
+
+func TestNamed_Missing(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Instance[string]("replica").As(replicaDB{}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryNew[*qualifiedRepository](ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %v", err)
+	}
+}
+
+func TestNamed_Constructor(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Instance[string]("primary").As(primaryDB{}),
+		bind.Instance[string]("replica").As(replicaDB{}),
+		bind.Constructor[[]string](func(p bind.Named[string, primaryDB], r bind.Named[string, replicaDB]) []string {
+			return []string{p.Get(), r.Get()}
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	if v := bind.Get[[]string](ctx); len(v) != 2 || v[0] != "primary" || v[1] != "replica" {
+		t.Errorf("expected [primary replica], got %v", v)
+	}
+}
+
+func TestNamed_InvalidTag(t *testing.T) {
+	type invalid struct {
+		DB bind.Named[string, primaryDB] `bind:"db"`
+	}
+
+	ctx, err := bind.Configure(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryNew[*invalid](ctx); !errors.Is(err, bind.ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag, got %v", err)
+	}
+}
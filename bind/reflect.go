@@ -1,10 +1,16 @@
 package bind
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
 
+var (
+	errorType   = typeOf[error]()
+	contextType = typeOf[context.Context]()
+)
+
 // typeOf returns the type of T.
 func typeOf[T any]() reflect.Type {
 	// HACK: Use a pointer here so that it works for interfaces too. 🤡
@@ -31,17 +37,21 @@ func alloc(t reflect.Type) (v reflect.Value, err error) {
 }
 
 // unboxValue v of type t.
+func unboxValue[T any](t reflect.Type, v reflect.Value) (res T) {
+	reflect.ValueOf(&res).Elem().Set(valueOf(t, v))
+	return
+}
+
+// valueOf v for type t.
 //
-// It is assumed that v is always a pointer to a value
-// whereas the type t not necessarily.
-func unboxValue[T any](t reflect.Type, v reflect.Value) T {
-	switch t.Kind() {
-	case reflect.Pointer,
-		reflect.Interface:
-		return v.Interface().(T)
-	default:
-		return *v.Interface().(*T)
+// The value v is either assignable to t or a pointer to a value
+// of t, e.g. if it has been allocated for t.
+func valueOf(t reflect.Type, v reflect.Value) reflect.Value {
+	if !v.Type().AssignableTo(t) {
+		return v.Elem()
 	}
+
+	return v
 }
 
 // assignableTo is true when B is assignable to A.
@@ -56,3 +66,35 @@ func mustBeAssignable[A, B any]() {
 		panic(fmt.Errorf("can't assign %s to %s", typeOf[B](), typeOf[A]()))
 	}
 }
+
+// checkConstructor returns an error if f isn't a constructor for t.
+//
+// A constructor is a non-variadic function which returns a value assignable
+// to t and optionally an error.
+func checkConstructor(t reflect.Type, f reflect.Value) error {
+	if f.Kind() != reflect.Func || f.IsNil() {
+		return fmt.Errorf("constructor for %s must be a function, got %s", t, f.Kind())
+	}
+
+	ft := f.Type()
+
+	if ft.IsVariadic() {
+		return fmt.Errorf("constructor %s for %s can't be variadic", ft, t)
+	}
+
+	switch ft.NumOut() {
+	case 2:
+		if ft.Out(1) != errorType {
+			return fmt.Errorf("constructor %s for %s must return an error as second result", ft, t)
+		}
+		fallthrough
+	case 1:
+		if !ft.Out(0).AssignableTo(t) {
+			return fmt.Errorf("can't assign %s to %s", ft.Out(0), t)
+		}
+	default:
+		return fmt.Errorf("constructor %s for %s must return one or two results", ft, t)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package bind
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// resolution tracks the bindings that are currently being solved.
+//
+// Each call to a public API that resolves a value starts a new
+// resolution. Nested dependencies are solved within the same
+// resolution which allows us to detect cycles.
+//
+// User code, like providers and handles, can start new resolutions
+// while a resolution is in progress. These resolutions are linked to
+// the resolution that called the user code so that cycles across
+// resolutions are detected as well.
+type resolution struct {
+	ctx     context.Context // context in which the resolution happens
+	derived context.Context // context passed to user code, see context
+	parent  *resolution     // resolution that started this one, if any
+	mut     sync.Mutex      // guards chain, resolutions started by this one read it
+	chain   []link
+	hooks   *hooks // hooks of the bindings of ctx, nil if there are none
+}
+
+// resolutionKey is the context key of the resolution that derived a context.
+type resolutionKey struct{}
+
+// link in the chain of a resolution.
+type link struct {
+	binding Binding
+	field   string // field that requested the binding, if any
+}
+
+// newResolution creates and returns an empty resolution in ctx.
+func newResolution(ctx context.Context) *resolution {
+	r := &resolution{ctx: ctx}
+	r.parent, _ = ctx.Value(resolutionKey{}).(*resolution)
+
+	if bs, loaded := fromCtx(ctx); loaded {
+		if h := &bs.settings().hooks; !h.empty() {
+			r.hooks = h
+		}
+	}
+
+	return r
+}
+
+// enter binding b which has been requested by field.
+//
+// An error wrapping ErrCycle is returned if b is already being solved by
+// r or by one of the resolutions that started it.
+func (r *resolution) enter(b Binding, field string) (err error) {
+	solving := r.solving(b)
+
+	r.mut.Lock()
+	r.chain = append(r.chain, link{binding: b, field: field})
+	r.mut.Unlock()
+
+	if solving {
+		err = &ResolutionError{Type: b.typ(), Scope: b.scope(), Err: fmt.Errorf("%w: %s", ErrCycle, r.chains())}
+		r.leave()
+	}
+
+	return
+}
+
+// solving is true if b is being solved by r or one of its ancestors.
+func (r *resolution) solving(b Binding) bool {
+	for p := r; p != nil; p = p.parent {
+		p.mut.Lock()
+
+		for _, l := range p.chain {
+			if l.binding == b {
+				p.mut.Unlock()
+				return true
+			}
+		}
+
+		p.mut.Unlock()
+	}
+
+	return false
+}
+
+// context passed to user code that is called during the resolution.
+//
+// The context is derived from the context of the resolution and links
+// resolutions that are started with it to r.
+func (r *resolution) context() context.Context {
+	if r.derived == nil {
+		r.derived = context.WithValue(r.ctx, resolutionKey{}, r)
+	}
+
+	return r.derived
+}
+
+// descends is true if r is ancestor or has been started by it,
+// directly or indirectly.
+func (r *resolution) descends(ancestor *resolution) bool {
+	for p := r; p != nil; p = p.parent {
+		if p == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cycle returns an error wrapping ErrCycle for type t that is already
+// being solved by a resolution that started r.
+//
+// The error lists the chains of all resolutions up to r.
+func (r *resolution) cycle(t reflect.Type) error {
+	return &ResolutionError{Type: t, Err: fmt.Errorf("%w: %s", ErrCycle, r.chains())}
+}
+
+// chains of all resolutions up to r, starting with the outermost one.
+func (r *resolution) chains() string {
+	var chains []string
+
+	for p := r; p != nil; p = p.parent {
+		if s := p.String(); s != "" {
+			chains = append([]string{s}, chains...)
+		}
+	}
+
+	return strings.Join(chains, " -> ")
+}
+
+// current binding that is being solved, nil if there is none.
+func (r *resolution) current() Binding {
+	if len(r.chain) == 0 {
+		return nil
+	}
+
+	return r.chain[len(r.chain)-1].binding
+}
+
+// path of struct fields leading to field, the fields of the chain
+// followed by field itself.
+func (r *resolution) path(field string) (res []string) {
+	for _, l := range r.chain {
+		if l.field != "" {
+			res = append(res, l.field)
+		}
+	}
+
+	if field != "" {
+		res = append(res, field)
+	}
+
+	return
+}
+
+// leave the binding that has been entered last.
+func (r *resolution) leave() {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.chain = r.chain[:len(r.chain)-1]
+}
+
+// String returns the chain of this resolution, e.g. "*A -> *B (field A.B)".
+func (r *resolution) String() string {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	var sb strings.Builder
+
+	for i, l := range r.chain {
+		if i > 0 {
+			sb.WriteString(" -> ")
+		}
+
+		sb.WriteString(l.binding.typ().String())
+
+		if l.field != "" {
+			sb.WriteString(" (field ")
+			sb.WriteString(l.field)
+			sb.WriteString(")")
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,195 @@
+package bind
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Scope identifies the lifetime of instances of scoped bindings.
+//
+// A scope is opened by a context with bind.Open. Instances of a scoped
+// binding are created once and cached by the nearest context that opened
+// the scope. Contexts configured from that context share the instances.
+//
+// Any comparable type can be used as a Scope. NewScope creates a unique
+// scope with a name.
+type Scope interface {
+	// String returns the name of the scope.
+	String() string
+}
+
+// Request scope for instances that are shared within a request.
+//
+// The scope must be opened for each request, e.g. in an HTTP middleware:
+//
+//  ctx, err := bind.Configure(r.Context(), bind.Open(bind.Request))
+var Request = NewScope("request")
+
+// NewScope creates and returns a new unique scope with a name.
+func NewScope(name string) Scope {
+	return &namedScope{name: name}
+}
+
+// namedScope is a scope that is only equal to itself.
+type namedScope struct {
+	name string
+}
+
+func (s *namedScope) String() string { return s.name }
+
+// Open - Open scope s in a context.
+//
+// This is an option of Configure. Scoped bindings resolved from the
+// configured context or its children cache their instances within this
+// context. These instances are disposed when the context is shut down.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.Scoped[*Transaction]())
+//
+//  func handle(w http.ResponseWriter, r *http.Request) {
+//    ctx, _ := bind.Configure(r.Context(), bind.Open(bind.Request))
+//    tx := bind.Get[*Transaction](ctx) // same instance for the entire request
+//  }
+func Open(s Scope) Binding {
+	return &openBind{s: s}
+}
+
+// Scoped - Bind T exactly once per opened Request scope.
+func Scoped[T any]() Binding {
+	return ImplementationScoped[T, T](Request)
+}
+
+// ImplementationScoped - Bind U exactly once per opened scope s for T.
+//
+// Resolving T fails with ErrScopeNotOpen if neither the context nor one
+// of its parents opened the scope s.
+func ImplementationScoped[T, U any](s Scope) Binding {
+	mustBeAssignable[T, U]()
+	return &scopedBind[T, U]{
+		s:        s,
+		typeFrom: typeOf[T](),
+		typeTo:   typeOf[U](),
+	}
+}
+
+// openBind is an option that opens a scope.
+type openBind struct {
+	s Scope
+}
+
+func (b *openBind) typ() reflect.Type { return nil }
+func (b *openBind) scope() string     { return "" }
+func (b *openBind) eager() bool       { return false }
+
+func (b *openBind) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	panic("bind: can't solve an option")
+}
+
+func (b *openBind) For(string) Binding { return b }
+func (b *openBind) As(any) Binding     { return b }
+
+func (b *openBind) configure(bs *bindings) (err error) {
+	if _, loaded := bs.scopes[b.s]; loaded {
+		return fmt.Errorf("%w: scope %s", ErrDuplicate, b.s)
+	}
+
+	if bs.scopes == nil {
+		bs.scopes = make(map[Scope]*scopeCache)
+	}
+
+	bs.scopes[b.s] = &scopeCache{}
+
+	return
+}
+
+// scopedBind represents a bind of a type From to type To that's solved once per scope.
+type scopedBind[From, To any] struct {
+	s        Scope
+	key      string
+	typeFrom reflect.Type
+	typeTo   reflect.Type
+}
+
+func (b *scopedBind[From, To]) typ() reflect.Type   { return b.typeFrom }
+func (b *scopedBind[From, To]) typTo() reflect.Type { return b.typeTo }
+func (b *scopedBind[From, To]) scope() string       { return b.key }
+func (b *scopedBind[From, To]) eager() bool         { return false }
+func (b *scopedBind[From, To]) kind() Kind          { return KindScoped }
+
+func (b *scopedBind[From, To]) deps() ([]dependency, error) { return fieldDeps(b.typeTo) }
+
+func (b *scopedBind[From, To]) solve(bs *bindings, r *resolution) (value reflect.Value, init bool, err error) {
+	owner, cache := bs.openedScope(b.s)
+
+	if cache == nil {
+		err = fmt.Errorf("%w: %s for %s", ErrScopeNotOpen, b.s, b.typeFrom)
+		return
+	}
+
+	// Dependencies are solved in the context that opened the scope since
+	// the instance is shared by all of its children.
+	value, err = cache.instance(b).get(owner, r, b.typeTo)
+	return
+}
+
+func (b *scopedBind[From, To]) instance(bs *bindings) *instance {
+	if _, cache := bs.openedScope(b.s); cache != nil {
+		return cache.instance(b)
+	}
+
+	return nil
+}
+
+func (b *scopedBind[From, To]) For(k string) Binding {
+	b.key = k
+	return b
+}
+
+func (b *scopedBind[From, To]) As(q any) Binding {
+	return b.For(qualifierOf(q))
+}
+
+// scopeCache holds the instances of scoped bindings for an opened scope
+// and the instances of the Once bindings of a context.
+type scopeCache struct {
+	mut       sync.Mutex
+	instances map[Binding]*instance
+}
+
+// instance of binding b.
+func (c *scopeCache) instance(b Binding) *instance {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	inst, loaded := c.instances[b]
+
+	if !loaded {
+		if c.instances == nil {
+			c.instances = make(map[Binding]*instance)
+		}
+
+		inst = &instance{}
+		c.instances[b] = inst
+	}
+
+	return inst
+}
+
+// openedScope returns the nearest bindings that opened the scope s.
+func (bs *bindings) openedScope(s Scope) (owner *bindings, cache *scopeCache) {
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+		cache = bb.scopes[s]
+		bb.mut.RUnlock()
+
+		if cache != nil {
+			owner = bb
+			return
+		}
+	}
+
+	return
+}
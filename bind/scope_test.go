@@ -0,0 +1,115 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type scopedTx struct {
+	User string `bind:"user"`
+}
+
+func TestScoped(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Scoped[*scopedTx]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryGet[*scopedTx](ctx); !errors.Is(err, bind.ErrScopeNotOpen) {
+		t.Errorf("expected ErrScopeNotOpen, got %s", err)
+	}
+
+	req1, err := bind.Configure(ctx, bind.Open(bind.Request), bind.String("alice").For("user"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	req2, err := bind.Configure(ctx, bind.Open(bind.Request), bind.String("bob").For("user"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	// a child of the request shares the instances of the request
+	child, err := bind.Configure(req1, bind.String("eve").For("user"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	var (
+		wg  sync.WaitGroup
+		txs = make([]*scopedTx, 8)
+	)
+
+	for i := range txs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			txs[i] = bind.Get[*scopedTx](req1)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, tx := range txs {
+		if tx != txs[0] {
+			t.Fatal("two different instances in the same scope")
+		}
+	}
+
+	if tx := bind.Get[*scopedTx](child); tx != txs[0] {
+		t.Error("expected the instance of the parent scope")
+	} else if tx.User != "alice" {
+		t.Errorf("expected alice, got %s", tx.User)
+	}
+
+	if tx := bind.Get[*scopedTx](req2); tx == txs[0] {
+		t.Error("expected different instances in different scopes")
+	} else if tx.User != "bob" {
+		t.Errorf("expected bob, got %s", tx.User)
+	}
+}
+
+func TestScopedCustom(t *testing.T) {
+	tenant := bind.NewScope("tenant")
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.ImplementationScoped[Iface, *Impl](tenant),
+		bind.Open(bind.Request))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryGet[Iface](ctx); !errors.Is(err, bind.ErrScopeNotOpen) {
+		t.Errorf("expected ErrScopeNotOpen, got %s", err)
+	}
+
+	ctx, err = bind.Configure(ctx, bind.Open(tenant))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if bind.Get[Iface](ctx) != bind.Get[Iface](ctx) {
+		t.Error("two different instances in the same scope")
+	}
+
+	if _, err = bind.Configure(ctx, bind.Open(tenant), bind.Open(tenant)); !errors.Is(err, bind.ErrDuplicate) {
+		t.Errorf("expected ErrDuplicate, got %s", err)
+	}
+}
@@ -0,0 +1,256 @@
+package bind
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// injectPrefix of methods that are called when an instance is initialized.
+const injectPrefix = "Inject"
+
+// Setter - Call fn for every instance of T that is initialized.
+//
+// The first parameter of fn receives the instance of T. All other
+// parameters are resolved like the parameters of a Constructor. A
+// parameter of type context.Context receives the context in which T
+// is resolved. Optionally fn may return an error.
+//
+// This is useful for types that can only be configured with setter
+// methods, like wrappers of third-party types. Types that are under
+// your control can declare methods named Inject followed by an upper-case
+// letter instead, e.g. InjectLogger(l *Logger). Their parameters are
+// resolved the same way. Methods like Injector or Injection aren't called
+// and neither are Inject methods that are variadic or return anything but
+// an error.
+//
+// Fields with a bind struct tag are injected first, followed by the
+// Inject methods in the order of their names, the setters and finally
+// InitAfter. Setters of parent contexts are called first, followed by
+// the setters of the child contexts in the order in which they are
+// configured. Only instances created by bind are initialized, hence
+// setters don't apply to instances of Instance bindings.
+//
+// Example
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.Type[*Logger](),
+//    bind.Type[*api.Client](),
+//    bind.Setter[*api.Client](func(c *api.Client, l *Logger) {
+//      c.SetLogger(l)
+//    }))
+//
+// Since the signature of fn can't be checked at compile time this
+// method panics if fn isn't a function of the above form.
+func Setter[T any](fn any) Binding {
+	f := reflect.ValueOf(fn)
+	t := typeOf[T]()
+
+	if err := checkSetter(t, f); err != nil {
+		panic(err)
+	}
+
+	return &setterBind{t: t, f: f}
+}
+
+// checkSetter checks that f is a setter function for type t.
+func checkSetter(t reflect.Type, f reflect.Value) error {
+	if f.Kind() != reflect.Func || f.IsNil() {
+		return fmt.Errorf("setter for %s must be a function, got %s", t, f.Kind())
+	}
+
+	ft := f.Type()
+
+	if ft.NumIn() == 0 || ft.In(0) != t {
+		return fmt.Errorf("setter %s for %s must accept %s as first parameter", ft, t, t)
+	}
+
+	return checkInjector(ft)
+}
+
+// checkInjector checks that the function type ft can be called by an
+// injector, i.e. it isn't variadic and returns nothing or an error.
+func checkInjector(ft reflect.Type) error {
+	if ft.IsVariadic() {
+		return fmt.Errorf("%s can't be variadic", ft)
+	}
+
+	if ft.NumOut() > 1 || ft.NumOut() == 1 && ft.Out(0) != errorType {
+		return fmt.Errorf("%s must return nothing or an error", ft)
+	}
+
+	return nil
+}
+
+// setterBind is an option that calls a setter for the instances of a type.
+type setterBind struct {
+	t reflect.Type
+	f reflect.Value
+}
+
+func (b *setterBind) typ() reflect.Type { return b.t }
+func (b *setterBind) scope() string     { return "" }
+func (b *setterBind) eager() bool       { return false }
+
+func (b *setterBind) deps() ([]dependency, error) {
+	return paramDeps(b.f.Type(), 1, ""), nil
+}
+
+func (b *setterBind) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	panic("bind: can't solve an option")
+}
+
+func (b *setterBind) For(string) Binding { return b }
+func (b *setterBind) As(any) Binding     { return b }
+
+func (b *setterBind) configure(bs *bindings) (err error) {
+	if bs.setters == nil {
+		bs.setters = make(map[reflect.Type][]*setterBind)
+	}
+
+	bs.setters[b.t] = append(bs.setters[b.t], b)
+
+	return
+}
+
+// paramDeps returns the dependencies of the parameters of the function
+// type ft from index first on.
+//
+// Parameters of type context.Context aren't dependencies.
+func paramDeps(ft reflect.Type, first int, field string) (deps []dependency) {
+	for i := first; i < ft.NumIn(); i++ {
+		if ft.In(i) == contextType {
+			continue
+		}
+
+		d := paramDependency(ft.In(i))
+		d.field = field
+		deps = append(deps, d)
+	}
+
+	return
+}
+
+// methodPlan is the injection plan of an Inject method.
+type methodPlan struct {
+	index int    // index of the method, see reflect.Value.Method
+	field string // name of the method including its type for errors
+}
+
+// planMethods returns the plans of the Inject methods of type t.
+func planMethods(t reflect.Type) (res []methodPlan) {
+	if t.Kind() == reflect.Interface {
+		return
+	}
+
+	owner := t
+
+	for owner.Kind() == reflect.Pointer {
+		owner = owner.Elem()
+	}
+
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+
+		if !isInjectMethod(m) {
+			continue
+		}
+
+		res = append(res, methodPlan{index: i, field: owner.Name() + "." + m.Name})
+	}
+
+	return
+}
+
+// isInjectMethod is true if the name of m is Inject followed by an
+// upper-case letter and m can be called by an injector.
+//
+// Other methods are skipped since they may just happen to share the
+// prefix, like Injector().
+func isInjectMethod(m reflect.Method) bool {
+	name, ok := strings.CutPrefix(m.Name, injectPrefix)
+
+	if !ok {
+		return false
+	}
+
+	if r, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(r) {
+		return false
+	}
+
+	return checkInjector(m.Type) == nil
+}
+
+// methodDeps returns the dependencies of the Inject methods of type t.
+func methodDeps(t reflect.Type) (deps []dependency) {
+	for _, mp := range planOf(t).methods {
+		// the method type includes the receiver as first parameter
+		deps = append(deps, paramDeps(t.Method(mp.index).Type, 1, mp.field)...)
+	}
+
+	return
+}
+
+// injectMethods calls the Inject methods of value and the setters of
+// bs and its parents for type typ.
+func (bs *bindings) injectMethods(r *resolution, typ reflect.Type, value reflect.Value) (err error) {
+	p := planOf(typ)
+
+	if p.derefs > 0 && value.IsNil() {
+		return
+	}
+
+	for _, mp := range p.methods {
+		if err = bs.call(r, value.Method(mp.index), mp.field); err != nil {
+			return
+		}
+	}
+
+	if !bs.settings().setters {
+		return
+	}
+
+	var setters []*setterBind
+
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+		layer := bb.setters[typ]
+		bb.mut.RUnlock()
+
+		setters = append(append([]*setterBind(nil), layer...), setters...)
+	}
+
+	for _, s := range setters {
+		if err = bs.call(r, s.f, "", value); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// call the function f with args followed by its remaining parameters
+// resolved in r and return its error, if any.
+//
+// The field is the name of the struct field or method that f belongs to.
+func (bs *bindings) call(r *resolution, f reflect.Value, field string, args ...reflect.Value) error {
+	ft := f.Type()
+
+	for i := len(args); i < ft.NumIn(); i++ {
+		v, err := bs.param(r, ft.In(i), field)
+
+		if err != nil {
+			return err
+		}
+
+		args = append(args, v)
+	}
+
+	if out := f.Call(args); len(out) == 1 && !out[0].IsNil() {
+		return out[0].Interface().(error)
+	}
+
+	return nil
+}
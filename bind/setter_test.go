@@ -0,0 +1,241 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type setterLogger struct {
+	Prefix string `bind:"prefix"`
+}
+
+type setterClient struct {
+	calls  []string
+	logger *setterLogger
+	ctx    context.Context
+	Host   string `bind:"host"`
+}
+
+func (c *setterClient) SetLogger(l *setterLogger) { c.logger = l }
+
+func (c *setterClient) InjectContext(ctx context.Context, name string) {
+	c.ctx = ctx
+	c.calls = append(c.calls, "InjectContext "+c.Host+" "+name)
+}
+
+func (c *setterClient) InjectLogger(l *setterLogger) error {
+	c.calls = append(c.calls, "InjectLogger "+l.Prefix)
+	return nil
+}
+
+func (c *setterClient) InitAfter() error {
+	c.calls = append(c.calls, "InitAfter")
+	return nil
+}
+
+func TestSetter(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("localhost").For("host"),
+		bind.String("log").For("prefix"),
+		bind.Instance[string]("name"),
+		bind.Type[*setterLogger](),
+		bind.Setter[*setterClient](func(c *setterClient, l *setterLogger) {
+			c.calls = append(c.calls, "parent setter")
+			c.SetLogger(l)
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	child, err := bind.Configure(ctx,
+		bind.Setter[*setterClient](func(c *setterClient, ctx context.Context) error {
+			c.calls = append(c.calls, "child setter")
+			return nil
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(child); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	c := bind.New[*setterClient](child)
+	exp := []string{"InjectContext localhost name", "InjectLogger log", "parent setter", "child setter", "InitAfter"}
+
+	if !reflect.DeepEqual(c.calls, exp) {
+		t.Errorf("expected %v, got %v", exp, c.calls)
+	}
+
+	if c.logger == nil || c.ctx == nil || bind.For[string](c.ctx, "host") != "localhost" {
+		t.Errorf("expected the logger and context to be injected, got %+v", c)
+	}
+
+	if c = bind.New[*setterClient](ctx); len(c.calls) != 4 {
+		t.Errorf("expected no setters of the child context, got %v", c.calls)
+	}
+}
+
+func TestSetter_Errors(t *testing.T) {
+	boom := errors.New("boom")
+
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("localhost").For("host"),
+		bind.Type[*setterClient](),
+		bind.Setter[*setterClient](func(*setterClient) error { return boom }))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding for the Inject methods, got %v", err)
+	}
+
+	var re *bind.ResolutionError
+
+	_, err = bind.TryNew[*setterClient](ctx)
+
+	if !errors.As(err, &re) || !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Fatalf("expected a *ResolutionError, got %v", err)
+		return
+	}
+
+	if exp := []string{"setterClient.InjectContext"}; !reflect.DeepEqual(re.Path, exp) {
+		t.Errorf("expected path %v, got %v", exp, re.Path)
+	}
+
+	ctx, err = bind.Configure(ctx,
+		bind.Instance[string]("name"),
+		bind.Type[*setterLogger](),
+		bind.String("log").For("prefix"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if _, err = bind.TryNew[*setterClient](ctx); !errors.Is(err, boom) {
+		t.Errorf("expected the error of the setter, got %v", err)
+	}
+}
+
+type setterLookalike struct {
+	calls []string
+}
+
+func (c *setterLookalike) Injector() int {
+	c.calls = append(c.calls, "Injector")
+	return 0
+}
+
+func (c *setterLookalike) Injection(s string) {
+	c.calls = append(c.calls, "Injection")
+}
+
+func (c *setterLookalike) InjectNames(names ...string) {
+	c.calls = append(c.calls, "InjectNames")
+}
+
+func (c *setterLookalike) InjectName(name string) int {
+	c.calls = append(c.calls, "InjectName")
+	return 0
+}
+
+func (c *setterLookalike) InjectHost(host string) error {
+	c.calls = append(c.calls, "InjectHost")
+	return nil
+}
+
+func TestSetter_InjectMethods(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("localhost"),
+		bind.Type[*setterLookalike]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	c, err := bind.TryGet[*setterLookalike](ctx)
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if exp := []string{"InjectHost"}; !reflect.DeepEqual(c.calls, exp) {
+		t.Errorf("expected %v, got %v", exp, c.calls)
+	}
+}
+
+type setterConfig struct {
+	Host string `bind:"host"`
+}
+
+type setterConfigured struct {
+	calls []string
+}
+
+func (c *setterConfigured) InjectConfig(cfg setterConfig) {
+	c.calls = append(c.calls, "InjectConfig "+cfg.Host)
+}
+
+func TestSetter_ValueParameter(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("localhost").For("host"),
+		bind.Type[setterConfig](),
+		bind.Type[*setterConfigured](),
+		bind.Setter[*setterConfigured](func(c *setterConfigured, cfg setterConfig) {
+			c.calls = append(c.calls, "setter "+cfg.Host)
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	c, err := bind.TryGet[*setterConfigured](ctx)
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if exp := []string{"InjectConfig localhost", "setter localhost"}; !reflect.DeepEqual(c.calls, exp) {
+		t.Errorf("expected %v, got %v", exp, c.calls)
+	}
+}
+
+func TestSetter_Invalid(t *testing.T) {
+	for _, fn := range []any{
+		nil,
+		func() {},
+		func(*setterLogger) {},
+		func(*setterClient) string { return "" },
+		func(*setterClient, ...string) {},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic for %T", fn)
+				}
+			}()
+
+			bind.Setter[*setterClient](fn)
+		}()
+	}
+}
@@ -0,0 +1,356 @@
+package bind
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = typeOf[time.Duration]()
+
+// FromEnv - Bind configuration values from environment variables.
+//
+// Values are looked up by key. The key of a value is converted into the
+// name of the environment variable by upper-casing it, replacing "." and
+// "-" with "_" and adding the prefix. For example the key "db.port" with
+// the prefix "APP_" is read from the environment variable APP_DB_PORT.
+//
+// The environment is read when the context is configured.
+//
+// See FromMap for more information on how values are converted.
+func FromEnv(prefix string) Binding {
+	return &sourceBind{
+		name: "environment",
+		load: func() (source, error) {
+			vars := make(mapSource)
+
+			for _, kv := range os.Environ() {
+				if k, v, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(k, prefix) {
+					vars[k] = v
+				}
+			}
+
+			return &envSource{prefix: prefix, vars: vars}, nil
+		},
+	}
+}
+
+// FromMap - Bind configuration values from a map.
+//
+// Configuration values are bound for their key and can be injected into
+// fields with the same scope. Values of nested maps are bound for the keys
+// joined by ".", e.g. "db.port" for {"db": {"port": 5432}}.
+//
+// Unlike other bindings the type of a configuration value is given by the
+// type that is requested and values are converted automatically. Supported
+// types are strings, booleans, all integer and float kinds, time.Duration
+// and slices of those. Strings are parsed and split by "," for slices,
+// an empty string is an empty slice.
+//
+// Explicit bindings always take precedence over configuration values.
+// Configuration values of child contexts take precedence over those of
+// their parents.
+//
+// Example
+//
+//  type sqlDBImpl struct {
+//    Host    string        `bind:"db.host"`
+//    Port    int           `bind:"db.port"`
+//    Timeout time.Duration `bind:"db.timeout"`
+//  }
+//
+//  ctx, _ = bind.Configure(ctx,
+//    bind.FromMap(map[string]any{
+//      "db": map[string]any{
+//        "host":    "localhost",
+//        "port":    "5432",
+//        "timeout": "5s",
+//      },
+//    }))
+func FromMap(values map[string]any) Binding {
+	return &sourceBind{
+		name: "map",
+		load: func() (source, error) {
+			res := make(mapSource)
+			res.flatten("", values)
+			return res, nil
+		},
+	}
+}
+
+// FromJSON - Bind configuration values from a JSON object.
+//
+// The object is read from r when the context is configured. Errors are
+// returned by Configure.
+//
+// See FromMap for more information on how values are converted.
+func FromJSON(r io.Reader) Binding {
+	return &sourceBind{
+		name: "JSON",
+		load: func() (source, error) {
+			var values map[string]any
+
+			if err := json.NewDecoder(r).Decode(&values); err != nil {
+				return nil, err
+			}
+
+			res := make(mapSource)
+			res.flatten("", values)
+			return res, nil
+		},
+	}
+}
+
+// source of configuration values.
+type source interface {
+	// lookup the raw value for key.
+	lookup(key string) (value any, ok bool)
+}
+
+// mapSource holds configuration values by key.
+type mapSource map[string]any
+
+func (s mapSource) lookup(key string) (value any, ok bool) {
+	value, ok = s[key]
+	return
+}
+
+// flatten values of nested maps into s using keys joined by ".".
+func (s mapSource) flatten(prefix string, values map[string]any) {
+	for k, v := range values {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+
+		if nested, ok := v.(map[string]any); ok {
+			s.flatten(k, nested)
+			continue
+		}
+
+		s[k] = v
+	}
+}
+
+// envSource holds configuration values of environment variables.
+type envSource struct {
+	prefix string
+	vars   mapSource
+}
+
+var envReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+func (s *envSource) lookup(key string) (value any, ok bool) {
+	return s.vars.lookup(s.prefix + strings.ToUpper(envReplacer.Replace(key)))
+}
+
+// sourceBind is an option that adds a source of configuration values.
+type sourceBind struct {
+	name string
+	load func() (source, error)
+}
+
+func (b *sourceBind) typ() reflect.Type { return nil }
+func (b *sourceBind) scope() string     { return "" }
+func (b *sourceBind) eager() bool       { return false }
+
+func (b *sourceBind) solve(*bindings, *resolution) (reflect.Value, bool, error) {
+	panic("bind: can't solve an option")
+}
+
+func (b *sourceBind) For(string) Binding { return b }
+func (b *sourceBind) As(any) Binding     { return b }
+
+func (b *sourceBind) configure(bs *bindings) error {
+	s, err := b.load()
+
+	if err != nil {
+		return fmt.Errorf("can't load configuration from %s: %w", b.name, err)
+	}
+
+	bs.sources = append(bs.sources, s)
+
+	return nil
+}
+
+// configValue of type t for key k of the nearest source in bs and its parents.
+//
+// The result is false if there is no such value.
+func (bs *bindings) configValue(t reflect.Type, k string) (res reflect.Value, ok bool, err error) {
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+		sources := bb.sources
+		bb.mut.RUnlock()
+
+		// sources configured last take precedence
+		for i := len(sources) - 1; i >= 0; i-- {
+			var raw any
+
+			if raw, ok = sources[i].lookup(k); !ok {
+				continue
+			}
+
+			if res, err = convert(raw, t); err != nil {
+				err = fmt.Errorf(`%w: "%s" to %s: %s`, ErrConversion, k, t, err)
+			}
+
+			return
+		}
+	}
+
+	return
+}
+
+// convert the raw configuration value to type t.
+func convert(raw any, t reflect.Type) (res reflect.Value, err error) {
+	if raw == nil {
+		err = errors.New("value is null")
+		return
+	}
+
+	v := reflect.ValueOf(raw)
+
+	if v.Type().AssignableTo(t) {
+		res = v
+		return
+	}
+
+	res = reflect.New(t).Elem()
+	s, isString := raw.(string)
+
+	if t == durationType && isString {
+		var d time.Duration
+		d, err = time.ParseDuration(s)
+		res.SetInt(int64(d))
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		switch v.Kind() {
+		case reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.String:
+			res.SetString(fmt.Sprint(raw))
+		default:
+			err = fmt.Errorf("unsupported value %T", raw)
+		}
+	case reflect.Bool:
+		var b bool
+
+		if isString {
+			b, err = strconv.ParseBool(strings.TrimSpace(s))
+		} else if v.Kind() == reflect.Bool {
+			b = v.Bool()
+		} else {
+			err = fmt.Errorf("unsupported value %T", raw)
+		}
+
+		res.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+
+		switch {
+		case isString:
+			i, err = strconv.ParseInt(strings.TrimSpace(s), 0, t.Bits())
+		case v.CanInt():
+			i = v.Int()
+		case v.CanUint() && v.Uint() <= math.MaxInt64:
+			i = int64(v.Uint())
+		case v.CanFloat() && v.Float() == math.Trunc(v.Float()) && math.Abs(v.Float()) < math.MaxInt64:
+			i = int64(v.Float())
+		default:
+			err = fmt.Errorf("unsupported value %v", raw)
+		}
+
+		if err == nil && res.OverflowInt(i) {
+			err = fmt.Errorf("%v overflows", raw)
+		}
+
+		res.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+
+		switch {
+		case isString:
+			u, err = strconv.ParseUint(strings.TrimSpace(s), 0, t.Bits())
+		case v.CanUint():
+			u = v.Uint()
+		case v.CanInt() && v.Int() >= 0:
+			u = uint64(v.Int())
+		case v.CanFloat() && v.Float() >= 0 && v.Float() == math.Trunc(v.Float()) && v.Float() < math.MaxUint64:
+			u = uint64(v.Float())
+		default:
+			err = fmt.Errorf("unsupported value %v", raw)
+		}
+
+		if err == nil && res.OverflowUint(u) {
+			err = fmt.Errorf("%v overflows", raw)
+		}
+
+		res.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+
+		switch {
+		case isString:
+			f, err = strconv.ParseFloat(strings.TrimSpace(s), t.Bits())
+		case v.CanFloat():
+			f = v.Float()
+		case v.CanInt():
+			f = float64(v.Int())
+		case v.CanUint():
+			f = float64(v.Uint())
+		default:
+			err = fmt.Errorf("unsupported value %T", raw)
+		}
+
+		if err == nil && res.OverflowFloat(f) {
+			err = fmt.Errorf("%v overflows", raw)
+		}
+
+		res.SetFloat(f)
+	case reflect.Slice:
+		var elems []any
+
+		switch {
+		case isString && strings.TrimSpace(s) == "":
+			// an empty value, e.g. APP_HOSTS=, is an empty slice
+		case isString:
+			for _, elem := range strings.Split(s, ",") {
+				elems = append(elems, strings.TrimSpace(elem))
+			}
+		case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				elems = append(elems, v.Index(i).Interface())
+			}
+		default:
+			err = fmt.Errorf("unsupported value %T", raw)
+			return
+		}
+
+		res = reflect.MakeSlice(t, len(elems), len(elems))
+
+		for i, elem := range elems {
+			var e reflect.Value
+
+			if e, err = convert(elem, t.Elem()); err != nil {
+				err = fmt.Errorf("element %d: %w", i, err)
+				return
+			}
+
+			res.Index(i).Set(e)
+		}
+	default:
+		err = errors.New("unsupported type")
+	}
+
+	return
+}
@@ -0,0 +1,180 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joa/goety/bind"
+)
+
+type sourceConfig struct {
+	Host    string        `bind:"db.host"`
+	Port    uint16        `bind:"db.port"`
+	Timeout time.Duration `bind:"db.timeout"`
+	Debug   bool          `bind:"debug"`
+	Ratio   float32       `bind:"ratio"`
+	Tags    []string      `bind:"tags"`
+	Ports   []int         `bind:"ports"`
+}
+
+func TestFromMap(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.FromMap(map[string]any{
+			"db": map[string]any{
+				"host":    "localhost",
+				"port":    "5432",
+				"timeout": "5s",
+			},
+			"debug": true,
+			"ratio": 0.5,
+			"tags":  "a, b",
+			"ports": []any{80, "443"},
+		}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+
+	cfg := bind.New[*sourceConfig](ctx)
+
+	if cfg.Host != "localhost" || cfg.Port != 5432 || cfg.Timeout != 5*time.Second {
+		t.Errorf("unexpected db config %+v", cfg)
+	}
+
+	if !cfg.Debug || cfg.Ratio != 0.5 {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	if len(cfg.Tags) != 2 || cfg.Tags[0] != "a" || cfg.Tags[1] != "b" {
+		t.Errorf("expected [a b], got %v", cfg.Tags)
+	}
+
+	if len(cfg.Ports) != 2 || cfg.Ports[0] != 80 || cfg.Ports[1] != 443 {
+		t.Errorf("expected [80 443], got %v", cfg.Ports)
+	}
+
+	// explicit bindings and children take precedence
+	child, err := bind.Configure(ctx,
+		bind.String("example.com").For("db.host"),
+		bind.FromMap(map[string]any{"db.port": 1234}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if cfg = bind.New[*sourceConfig](child); cfg.Host != "example.com" || cfg.Port != 1234 {
+		t.Errorf("unexpected db config %+v", cfg)
+	}
+
+	if act := bind.For[string](child, "db.timeout"); act != "5s" {
+		t.Errorf("expected 5s, got %s", act)
+	}
+}
+
+func TestFromMapConversionError(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.FromMap(map[string]any{"db.port": 65536}))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	_, err = bind.TryFor[uint16](ctx, "db.port")
+
+	if !errors.Is(err, bind.ErrConversion) {
+		t.Fatalf("expected ErrConversion, got %s", err)
+	}
+
+	if msg := err.Error(); !strings.Contains(msg, `"db.port" to uint16`) {
+		t.Errorf("expected key and type in error, got %s", msg)
+	}
+
+	if _, err = bind.TryFor[string](ctx, "db.host"); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %s", err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("BIND_TEST_DB_HOST", "localhost")
+	t.Setenv("BIND_TEST_DB_PORT", "0x10")
+	t.Setenv("BIND_TEST_RATIO", "1e-1")
+
+	ctx, err := bind.Configure(context.Background(), bind.FromEnv("BIND_TEST_"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := bind.For[string](ctx, "db.host"); act != "localhost" {
+		t.Errorf("expected localhost, got %s", act)
+	}
+
+	if act := bind.For[int](ctx, "db-port"); act != 16 {
+		t.Errorf("expected 16, got %d", act)
+	}
+
+	if act := bind.For[float64](ctx, "ratio"); act != 0.1 {
+		t.Errorf("expected 0.1, got %f", act)
+	}
+}
+
+func TestFromEnv_EmptySlice(t *testing.T) {
+	t.Setenv("BIND_TEST_TAGS", "")
+	t.Setenv("BIND_TEST_PORTS", " ")
+
+	ctx, err := bind.Configure(context.Background(), bind.FromEnv("BIND_TEST_"))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act, err := bind.TryFor[[]string](ctx, "tags"); err != nil || act == nil || len(act) != 0 {
+		t.Errorf("expected an empty slice, got %q (%v)", act, err)
+	}
+
+	if act, err := bind.TryFor[[]int](ctx, "ports"); err != nil || len(act) != 0 {
+		t.Errorf("expected an empty slice, got %v (%v)", act, err)
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.FromJSON(strings.NewReader(`{"db": {"port": 5432, "timeout": 1000}, "ports": [1, 2]}`)))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if act := bind.For[int](ctx, "db.port"); act != 5432 {
+		t.Errorf("expected 5432, got %d", act)
+	}
+
+	if act := bind.For[time.Duration](ctx, "db.timeout"); act != time.Microsecond {
+		t.Errorf("expected 1µs, got %s", act)
+	}
+
+	if act := bind.For[[]uint](ctx, "ports"); len(act) != 2 || act[1] != 2 {
+		t.Errorf("expected [1 2], got %v", act)
+	}
+
+	if _, err = bind.TryFor[int](ctx, "ports"); !errors.Is(err, bind.ErrConversion) {
+		t.Errorf("expected ErrConversion, got %s", err)
+	}
+
+	if _, err = bind.Configure(ctx, bind.FromJSON(strings.NewReader("{"))); err == nil {
+		t.Error("expected an error")
+	}
+}
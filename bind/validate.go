@@ -0,0 +1,265 @@
+package bind
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// dependency of a binding on another binding.
+type dependency struct {
+	tagOptions
+	typ    reflect.Type
+	field  string       // name of the struct field that depends on typ, if any
+	deep   bool         // the field is only injected with Deep
+	named  reflect.Type // the Named type that holds the instance, if any
+	handle reflect.Type // the Lazy or Factory type that resolves typ, if any
+}
+
+// defaultValue of the dependency converted to its type.
+func (d dependency) defaultValue() (res reflect.Value, err error) {
+	if res, err = convert(d.def, d.typ); err != nil {
+		err = fmt.Errorf(`%w: default "%s" to %s (field %s): %s`, ErrConversion, d.def, d.typ, d.field, err)
+	}
+
+	return
+}
+
+// box the resolved value v into the Named type of d, if any.
+func (d dependency) box(v reflect.Value) reflect.Value {
+	if d.named == nil {
+		return v
+	}
+
+	res := reflect.New(d.named)
+	res.Interface().(qualifiedValue).set(v)
+
+	return res.Elem()
+}
+
+// bindingDeps is implemented by bindings that depend on other bindings
+// when they are solved.
+type bindingDeps interface {
+	// deps are the dependencies of this binding.
+	deps() ([]dependency, error)
+}
+
+// fieldDeps returns the dependencies of type t given by its bind struct tags
+// and its Inject methods.
+//
+// This includes the dependencies that are only injected with Deep.
+func fieldDeps(t reflect.Type) (deps []dependency, err error) {
+	// instances of structs are allocated as pointers
+	methods := t
+
+	if t.Kind() == reflect.Struct {
+		methods = reflect.PointerTo(t)
+	}
+
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var errs []error
+
+	for _, fp := range planOf(t).fields {
+		if fp.err != nil {
+			errs = append(errs, fp.err)
+			continue
+		}
+
+		deps = append(deps, fp.dep)
+	}
+
+	deps = append(deps, methodDeps(methods)...)
+	err = errors.Join(errs...)
+
+	return
+}
+
+// visit state of a binding during validation.
+type visit int
+
+const (
+	visiting visit = iota + 1 // the binding's dependencies are being validated
+	visited                   // the binding has been validated
+)
+
+// validate all bindings visible in bs.
+//
+// All errors are collected and returned at once as a *ValidationError.
+func (bs *bindings) validate() error {
+	var (
+		errs    []*ResolutionError
+		visited = make(map[Binding]visit)
+	)
+
+	for _, b := range bs.visible() {
+		errs = append(errs, bs.validateBinding(b, visited, nil)...)
+	}
+
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+		multi := bb.multi
+		setters := bb.setters
+		bb.mut.RUnlock()
+
+		for _, contributions := range multi {
+			for _, c := range contributions {
+				errs = append(errs, bs.validateBinding(c.b, visited, nil)...)
+			}
+		}
+
+		for _, layer := range setters {
+			for _, s := range layer {
+				errs = append(errs, bs.validateBinding(s, visited, nil)...)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: errs}
+}
+
+// validateBinding b and all its dependencies.
+//
+// The path contains the fields that lead to b.
+func (bs *bindings) validateBinding(b Binding, state map[Binding]visit, path []string) (errs []*ResolutionError) {
+	b = bs.concrete(b)
+
+	switch state[b] {
+	case visiting:
+		errs = append(errs, pathError(fmt.Errorf("%w: %s", ErrCycle, b.typ()), b.typ(), b.scope(), path))
+		return
+	case visited:
+		return
+	}
+
+	state[b] = visiting
+	defer func() { state[b] = visited }()
+
+	if to, ok := b.(bindingTo); ok && to.typTo().Kind() == reflect.Interface {
+		errs = append(errs, pathError(bindingError(ErrUnsatisfiedInterface, to.typTo(), ""), to.typTo(), "", path))
+		return
+	}
+
+	d, ok := b.(bindingDeps)
+
+	if !ok {
+		return
+	}
+
+	deps, err := d.deps()
+
+	if err != nil {
+		errs = append(errs, pathError(err, b.typ(), b.scope(), path))
+	}
+
+	deep := bs.deep()
+
+	for _, dep := range deps {
+		if dep.deep && !deep {
+			continue
+		}
+
+		depPath := path
+
+		if dep.field != "" {
+			depPath = append(path[:len(path):len(path)], dep.field)
+		}
+
+		next, found := findBinding(bs, dep.typ, dep.scope)
+
+		// Handles resolve their dependency when they are used, hence
+		// they don't need to be validated recursively and can't cycle.
+		if found {
+			if dep.handle == nil {
+				errs = append(errs, bs.validateBinding(next, state, depPath)...)
+			}
+
+			continue
+		}
+
+		if dep.scope != "" {
+			if _, ok, err := bs.configValue(dep.typ, dep.scope); ok {
+				if err != nil {
+					errs = append(errs, pathError(err, dep.typ, dep.scope, depPath))
+				}
+
+				continue
+			}
+		}
+
+		contributions := bs.contributions(dep.typ)
+
+		if len(contributions) == 0 || dep.scope != "" {
+			switch {
+			case dep.optional:
+			case dep.hasDefault:
+				if _, err := dep.defaultValue(); err != nil {
+					errs = append(errs, pathError(err, dep.typ, dep.scope, depPath))
+				}
+			default:
+				errs = append(errs, pathError(bindingError(ErrNoSuchBinding, dep.typ, dep.scope), dep.typ, dep.scope, depPath))
+			}
+
+			continue
+		}
+
+		if dep.handle != nil {
+			continue
+		}
+
+		for _, c := range contributions {
+			errs = append(errs, bs.validateBinding(c.b, state, depPath)...)
+		}
+	}
+
+	return
+}
+
+// visible returns all bindings of bs and its parents that aren't
+// shadowed by a child, ordered by type and scope.
+func (bs *bindings) visible() (res []Binding) {
+	var all []Binding
+
+	for bb := bs; bb != nil; bb = bb.parent {
+		bb.mut.RLock()
+
+		for _, typeScope := range bb.bindings {
+			for _, b := range typeScope {
+				all = append(all, b)
+			}
+		}
+
+		bb.mut.RUnlock()
+	}
+
+	for _, b := range all {
+		if found, _ := findBinding(bs, b.typ(), b.scope()); found == b {
+			res = append(res, b)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		ti, tj := res[i].typ().String(), res[j].typ().String()
+
+		if ti == tj {
+			return res[i].scope() < res[j].scope()
+		}
+
+		return ti < tj
+	})
+
+	return
+}
+
+// pathError wraps err for type t and key k with the path of fields
+// that lead to it.
+func pathError(err error, t reflect.Type, k string, path []string) *ResolutionError {
+	return &ResolutionError{Type: t, Scope: k, Path: path, Err: err}
+}
@@ -0,0 +1,91 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/joa/goety/bind"
+)
+
+type validateDB struct {
+	Username string `bind:"username"`
+	Password string `bind:"password"`
+}
+
+type validateDao struct {
+	DB    *validateDB `bind:"-"`
+	Other IfaceB      `bind:"-"`
+}
+
+func TestValidate(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("admin").For("username"),
+		bind.String("s3cr3t").For("password"),
+		bind.Once[*validateDB](),
+		bind.Implementation[IfaceB, *ImplAB](),
+		bind.Type[*validateDao]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+}
+
+func TestValidateAggregate(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.String("admin").For("username"),
+		bind.Implementation[IfaceA, IfaceB](),
+		bind.Type[*validateDB](),
+		bind.Type[*validateDao]())
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	err = bind.Validate(ctx)
+
+	if !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %s", err)
+	}
+
+	if !errors.Is(err, bind.ErrUnsatisfiedInterface) {
+		t.Errorf("expected ErrUnsatisfiedInterface, got %s", err)
+	}
+
+	if msg := err.Error(); !strings.Contains(msg, `string for "password" (validateDB.Password)`) {
+		t.Errorf("expected missing password, got %s", msg)
+	}
+
+	if msg := err.Error(); !strings.Contains(msg, "bind_test.IfaceB (validateDao.Other)") {
+		t.Errorf("expected missing IfaceB, got %s", msg)
+	}
+}
+
+func TestValidateConstructor(t *testing.T) {
+	ctx, err := bind.Configure(context.Background(),
+		bind.Constructor[*ctorService](newCtorService))
+
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	if err = bind.Validate(ctx); !errors.Is(err, bind.ErrNoSuchBinding) {
+		t.Errorf("expected ErrNoSuchBinding, got %s", err)
+	}
+
+	if _, err = bind.Configure(ctx, bind.String("name")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = bind.Validate(context.Background()); !errors.Is(err, bind.ErrContextWithoutBindings) {
+		t.Errorf("expected ErrContextWithoutBindings, got %s", err)
+	}
+}